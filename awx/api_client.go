@@ -0,0 +1,171 @@
+package awx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+// apiClient sends requests to AWX API endpoints which are not covered by
+// goawx. It shares the HTTP client, and with it the authentication and TLS
+// settings, of the goawx client built in providerConfigure.
+type apiClient struct {
+	hostname string
+	client   *http.Client
+}
+
+// apiError is returned by apiClient for every non 2xx response.
+type apiError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s %s: %d %s: %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// apiListResponse is the paginated envelope of AWX list endpoints.
+type apiListResponse struct {
+	Count    int                      `json:"count"`
+	Next     string                   `json:"next"`
+	Previous string                   `json:"previous"`
+	Results  []map[string]interface{} `json:"results"`
+}
+
+var (
+	apiClientsMutex sync.Mutex
+	apiClients      = make(map[*awx.AWX]*apiClient)
+)
+
+func newAPIClient(hostname string, client *http.Client) *apiClient {
+	return &apiClient{
+		hostname: strings.TrimRight(hostname, "/"),
+		client:   client,
+	}
+}
+
+// registerAPIClient links the raw API client to the goawx client which is
+// handed to resources as provider meta.
+func registerAPIClient(c *awx.AWX, a *apiClient) {
+	apiClientsMutex.Lock()
+	defer apiClientsMutex.Unlock()
+	apiClients[c] = a
+}
+
+// getAPIClient returns the raw API client for the provider meta.
+func getAPIClient(m interface{}) *apiClient {
+	apiClientsMutex.Lock()
+	defer apiClientsMutex.Unlock()
+	return apiClients[m.(*awx.AWX)]
+}
+
+func isNotFound(err error) bool {
+	if e, ok := err.(*apiError); ok {
+		return e.StatusCode == http.StatusNotFound
+	}
+	return err != nil && strings.Contains(err.Error(), "404 Not Found")
+}
+
+func (a *apiClient) do(ctx context.Context, method, path string, params map[string]string, payload, out interface{}) error {
+	u := a.hostname + path
+	if len(params) > 0 {
+		q := url.Values{}
+		for k, v := range params {
+			q.Set(k, v)
+		}
+		u += "?" + q.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &apiError{
+			Method:     method,
+			Path:       path,
+			StatusCode: resp.StatusCode,
+			Body:       string(data),
+		}
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+func (a *apiClient) get(ctx context.Context, path string, params map[string]string, out interface{}) error {
+	return a.do(ctx, http.MethodGet, path, params, nil, out)
+}
+
+func (a *apiClient) post(ctx context.Context, path string, payload, out interface{}) error {
+	return a.do(ctx, http.MethodPost, path, nil, payload, out)
+}
+
+func (a *apiClient) patch(ctx context.Context, path string, payload, out interface{}) error {
+	return a.do(ctx, http.MethodPatch, path, nil, payload, out)
+}
+
+// options fetches the OPTIONS metadata of an endpoint, which describes its
+// fields and their defaults.
+func (a *apiClient) options(ctx context.Context, path string, out interface{}) error {
+	return a.do(ctx, http.MethodOptions, path, nil, nil, out)
+}
+
+func (a *apiClient) delete(ctx context.Context, path string) error {
+	return a.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// list fetches every page of an AWX list endpoint.
+func (a *apiClient) list(ctx context.Context, path string, params map[string]string) ([]map[string]interface{}, error) {
+	var results []map[string]interface{}
+	query := make(map[string]string)
+	for k, v := range params {
+		query[k] = v
+	}
+	for page := 1; ; page++ {
+		query["page"] = fmt.Sprint(page)
+		var res apiListResponse
+		if err := a.get(ctx, path, query, &res); err != nil {
+			return nil, err
+		}
+		results = append(results, res.Results...)
+		if res.Next == "" {
+			return results, nil
+		}
+	}
+}
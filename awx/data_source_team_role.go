@@ -0,0 +1,106 @@
+/*
+*TBD*
+
+# Example Usage
+
+```hcl
+
+	data "awx_team" "ops" {
+	  name = "ops"
+	}
+
+	data "awx_team_role" "ops_member" {
+	  name    = "Member"
+	  team_id = data.awx_team.ops.id
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// awxObjectRole is an entry of summary_fields.object_roles.
+type awxObjectRole struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func dataSourceTeamRole() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTeamRoleRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"team_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+		},
+	}
+}
+
+func dataSourceTeamRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	teamID := d.Get("team_id").(int)
+
+	var team struct {
+		SummaryFields struct {
+			ObjectRoles map[string]*awxObjectRole `json:"object_roles"`
+		} `json:"summary_fields"`
+	}
+	err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/teams/%d/", teamID), nil, &team)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Get: Fail to fetch Team",
+			"Fail to find the team, got: %s",
+			err.Error(),
+		)
+	}
+
+	if roleID, okID := d.GetOk("id"); okID {
+		id := roleID.(int)
+		for _, v := range team.SummaryFields.ObjectRoles {
+			if v != nil && id == v.ID {
+				d = setTeamRoleData(d, v)
+				return diags
+			}
+		}
+	}
+
+	if roleName, okName := d.GetOk("name"); okName {
+		name := roleName.(string)
+		for _, v := range team.SummaryFields.ObjectRoles {
+			if v != nil && name == v.Name {
+				d = setTeamRoleData(d, v)
+				return diags
+			}
+		}
+	}
+
+	return buildDiagnosticsMessage(
+		"Failed to fetch team role - Not Found",
+		"The team role was not found",
+	)
+}
+
+func setTeamRoleData(d *schema.ResourceData, r *awxObjectRole) *schema.ResourceData {
+	d.Set("name", r.Name)
+	d.SetId(strconv.Itoa(r.ID))
+	return d
+}
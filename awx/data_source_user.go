@@ -0,0 +1,105 @@
+/*
+*TBD*
+
+# Example Usage
+
+```hcl
+
+	data "awx_user" "jdoe" {
+	  username = "jdoe"
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceUser() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUserRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"first_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_superuser": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"is_system_auditor": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	params := make(map[string]string)
+	if username, okName := d.GetOk("username"); okName {
+		params["username"] = username.(string)
+	}
+
+	if userID, okUserID := d.GetOk("id"); okUserID {
+		params["id"] = strconv.Itoa(userID.(int))
+	}
+
+	if len(params) == 0 {
+		return buildDiagnosticsMessage(
+			"Get: Missing Parameters",
+			"Please use one of the selectors (username or id)",
+		)
+	}
+
+	users, err := getAPIClient(m).list(ctx, "/api/v2/users/", params)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Get: Fail to fetch User",
+			"Fail to find the user got: %s",
+			err.Error(),
+		)
+	}
+	if len(users) != 1 {
+		return buildDiagnosticsMessage(
+			"Get: find not exactly one Element",
+			"The Query Returns %d users, expected exactly one",
+			len(users),
+		)
+	}
+
+	user := users[0]
+	d.Set("username", user["username"])
+	d.Set("email", user["email"])
+	d.Set("first_name", user["first_name"])
+	d.Set("last_name", user["last_name"])
+	d.Set("is_superuser", user["is_superuser"])
+	d.Set("is_system_auditor", user["is_system_auditor"])
+	d.SetId(strconv.Itoa(int(user["id"].(float64))))
+	return diags
+}
@@ -0,0 +1,729 @@
+package awx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// fakeAWX is an in-process stand-in for the subset of the AWX /api/v2/ API
+// used by the provider. Objects are kept in memory as decoded JSON, foreign
+// keys are normalized to numbers like AWX does, and associations made
+// through related endpoints are tracked per parent object.
+type fakeAWX struct {
+	server *httptest.Server
+
+	mu          sync.Mutex
+	lastID      int
+	collections map[string]map[int]map[string]interface{}
+	related     map[string][]int
+	surveys     map[string]map[string]interface{}
+	settings    map[string]interface{}
+}
+
+// fakeForeignKeys are the fields AWX returns as numeric object IDs.
+var fakeForeignKeys = []string{
+	"organization",
+	"inventory",
+	"project",
+	"credential",
+	"credential_type",
+	"execution_environment",
+	"default_environment",
+	"webhook_credential",
+	"unified_job_template",
+	"workflow_job_template",
+	"job_template",
+	"source_project",
+	"source_credential",
+	"target_credential",
+	"team",
+	"user",
+}
+
+// fakeObjectRoles are created for every object which has object roles.
+var fakeObjectRoles = map[string]string{
+	"admin_role":   "Admin",
+	"execute_role": "Execute",
+	"member_role":  "Member",
+	"read_role":    "Read",
+	"update_role":  "Update",
+	"use_role":     "Use",
+}
+
+// fakeManagedCredentialTypes are created in this order by every fake AWX,
+// so Machine gets the ID 1 like in a fresh AWX.
+var fakeManagedCredentialTypes = []map[string]interface{}{
+	{"name": "Machine", "namespace": "ssh", "kind": "ssh", "managed": true, "inputs": map[string]interface{}{
+		"fields": []interface{}{
+			map[string]interface{}{"id": "username", "type": "string"},
+			map[string]interface{}{"id": "password", "type": "string", "secret": true},
+			map[string]interface{}{"id": "ssh_key_data", "type": "string", "secret": true, "format": "ssh_private_key"},
+			map[string]interface{}{"id": "ssh_public_key_data", "type": "string"},
+			map[string]interface{}{"id": "ssh_key_unlock", "type": "string", "secret": true},
+			map[string]interface{}{"id": "become_method", "type": "string"},
+			map[string]interface{}{"id": "become_username", "type": "string"},
+			map[string]interface{}{"id": "become_password", "type": "string", "secret": true},
+		},
+	}},
+	{"name": "Source Control", "namespace": "scm", "kind": "scm", "managed": true},
+	{"name": "Vault", "namespace": "vault", "kind": "vault", "managed": true},
+	{"name": "Network", "namespace": "net", "kind": "net", "managed": true},
+	{"name": "Amazon Web Services", "namespace": "aws", "kind": "cloud", "managed": true},
+	{"name": "Insights", "namespace": "insights", "kind": "insights", "managed": true},
+	{"name": "Red Hat Ansible Automation Platform", "namespace": "controller", "kind": "cloud", "managed": true},
+	{"name": "OpenShift or Kubernetes API Bearer Token", "namespace": "kubernetes_bearer_token", "kind": "kubernetes", "managed": true},
+	{"name": "Container Registry", "namespace": "registry", "kind": "registry", "managed": true},
+	{"name": "Ansible Galaxy/Automation Hub API Token", "namespace": "galaxy_api_token", "kind": "galaxy", "managed": true},
+	{"name": "CyberArk Central Credential Provider Lookup", "namespace": "aim", "kind": "external", "managed": true},
+	{"name": "HashiCorp Vault Secret Lookup", "namespace": "hashivault_kv", "kind": "external", "managed": true},
+	{"name": "HashiCorp Vault Signed SSH", "namespace": "hashivault_ssh", "kind": "external", "managed": true},
+	{"name": "Thycotic DevOps Secrets Vault", "namespace": "thycotic_dsv", "kind": "external", "managed": true},
+	{"name": "Thycotic Secret Server", "namespace": "thycotic_tss", "kind": "external", "managed": true},
+}
+
+var fakeRoleCollections = map[string]bool{
+	"organizations":          true,
+	"inventories":            true,
+	"projects":               true,
+	"job_templates":          true,
+	"workflow_job_templates": true,
+	"teams":                  true,
+	"credentials":            true,
+}
+
+func newFakeAWX(t *testing.T) *fakeAWX {
+	f := &fakeAWX{
+		collections: make(map[string]map[int]map[string]interface{}),
+		related:     make(map[string][]int),
+		surveys:     make(map[string]map[string]interface{}),
+		settings:    fakeSettingDefaults(),
+	}
+	for _, credentialType := range fakeManagedCredentialTypes {
+		f.create("credential_types", credentialType)
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func testProviderFactories() map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"awx": func() (*schema.Provider, error) {
+			return Provider(), nil
+		},
+	}
+}
+
+// config prefixes a test configuration with a provider block pointing to
+// the fake server.
+func (f *fakeAWX) config(cfg string) string {
+	return fmt.Sprintf(`
+provider "awx" {
+  hostname = %q
+  username = "admin"
+  password = "password"
+}
+`, f.server.URL) + cfg
+}
+
+func (f *fakeAWX) exists(collection, id string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return false
+	}
+	_, ok := f.collections[collection][n]
+	return ok
+}
+
+// checkDestroy verifies that every resource of the given type was removed
+// from the fake server.
+func (f *fakeAWX) checkDestroy(resourceType, collection string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			if f.exists(collection, rs.Primary.ID) {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+// checkField verifies a field of the object behind a resource as stored by
+// the fake server.
+func (f *fakeAWX) checkField(name, collection, field string, want interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		id, _ := strconv.Atoi(rs.Primary.ID)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		obj, ok := f.collections[collection][id]
+		if !ok {
+			return fmt.Errorf("%s %d not found", collection, id)
+		}
+		if got := fmt.Sprint(obj[field]); got != fmt.Sprint(want) {
+			return fmt.Errorf("%s %d: expected %s to be %v, got %s", collection, id, field, want, got)
+		}
+		return nil
+	}
+}
+
+// checkRelated verifies that the object behind a resource is associated with
+// the object behind another resource through the given related endpoint.
+func (f *fakeAWX) checkRelated(name, collection, sub, relatedName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		parent, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		related, ok := s.RootModule().Resources[relatedName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", relatedName)
+		}
+		id, _ := strconv.Atoi(related.Primary.ID)
+		key := fmt.Sprintf("%s/%s/%s", collection, parent.Primary.ID, sub)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if !fakeContainsID(f.related[key], id) {
+			return fmt.Errorf("%s %d is not associated with %s", sub, id, strings.TrimSuffix(key, "/"+sub))
+		}
+		return nil
+	}
+}
+
+// checkNotRelated verifies that the object behind a resource is not
+// associated with the object behind another resource anymore.
+func (f *fakeAWX) checkNotRelated(name, collection, sub, relatedName string) resource.TestCheckFunc {
+	check := f.checkRelated(name, collection, sub, relatedName)
+	return func(s *terraform.State) error {
+		if err := check(s); err == nil {
+			return fmt.Errorf("%s of %s is still associated with %s", sub, name, relatedName)
+		}
+		return nil
+	}
+}
+
+// associate associates the object behind a resource with the object behind
+// another resource through the given related endpoint, like a change made
+// in the AWX UI.
+func (f *fakeAWX) associate(name, collection, sub, relatedName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		parent, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		related, ok := s.RootModule().Resources[relatedName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", relatedName)
+		}
+		id, _ := strconv.Atoi(related.Primary.ID)
+		key := fmt.Sprintf("%s/%s/%s", collection, parent.Primary.ID, sub)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if !fakeContainsID(f.related[key], id) {
+			f.related[key] = append(f.related[key], id)
+		}
+		return nil
+	}
+}
+
+// checkSurvey verifies the variables asked by the survey spec of the object
+// behind a resource, in order.
+func (f *fakeAWX) checkSurvey(name, collection string, variables ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var got []string
+		if survey, ok := f.surveys[fmt.Sprintf("%s/%s/survey_spec", collection, rs.Primary.ID)]; ok {
+			spec, _ := survey["spec"].([]interface{})
+			for _, q := range spec {
+				got = append(got, fakeString(q.(map[string]interface{})["variable"]))
+			}
+		}
+		if strings.Join(got, ",") != strings.Join(variables, ",") {
+			return fmt.Errorf("expected survey of %s to ask for %v, got %v", name, variables, got)
+		}
+		return nil
+	}
+}
+
+// checkSurveyQuestion verifies a field of the survey question asking for
+// variable, nil checks that the field is not set.
+func (f *fakeAWX) checkSurveyQuestion(name, collection, variable, field string, want interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		survey := f.surveys[fmt.Sprintf("%s/%s/survey_spec", collection, rs.Primary.ID)]
+		spec, _ := survey["spec"].([]interface{})
+		for _, q := range spec {
+			question := q.(map[string]interface{})
+			if fakeString(question["variable"]) != variable {
+				continue
+			}
+			got, ok := question[field]
+			if want == nil && ok || want != nil && fmt.Sprint(got) != fmt.Sprint(want) {
+				return fmt.Errorf("expected %s of survey question %s of %s to be %v, got %v", field, variable, name, want, got)
+			}
+			return nil
+		}
+		return fmt.Errorf("survey of %s does not ask for %s", name, variable)
+	}
+}
+
+// checkSetting verifies the value of a setting stored by the fake server.
+func (f *fakeAWX) checkSetting(name string, want interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if got := fmt.Sprint(f.settings[name]); got != fmt.Sprint(want) {
+			return fmt.Errorf("expected setting %s to be %v, got %s", name, want, got)
+		}
+		return nil
+	}
+}
+
+func (f *fakeAWX) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body map[string]interface{}
+	data, _ := io.ReadAll(r.Body)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &body); err != nil {
+			writeFakeJSON(w, http.StatusBadRequest, map[string]interface{}{"detail": err.Error()})
+			return
+		}
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v2/"), "/")
+	parts := strings.Split(path, "/")
+	switch {
+	case path == "ping":
+		writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+			"ha":          false,
+			"version":     "21.0.0",
+			"active_node": "awx",
+		})
+	case path == "me":
+		writeFakeJSON(w, http.StatusOK, fakeList([]map[string]interface{}{
+			{"id": 1, "username": "admin", "is_superuser": true},
+		}))
+	case parts[0] == "settings":
+		f.handleSettings(w, r, body)
+	case len(parts) == 1:
+		f.handleCollection(w, r, parts[0], body)
+	case len(parts) == 2:
+		f.handleObject(w, r, parts[0], parts[1], body)
+	case len(parts) == 3:
+		f.handleRelated(w, r, parts[0], parts[1], parts[2], body)
+	default:
+		writeFakeNotFound(w)
+	}
+}
+
+// fakeSettingDefaults returns the settings of a fresh fake AWX, which are
+// also reported as defaults in the OPTIONS metadata.
+func fakeSettingDefaults() map[string]interface{} {
+	return map[string]interface{}{
+		"SCHEDULE_MAX_JOBS":   float64(10),
+		"AUTH_LDAP_TEAM_MAP":  map[string]interface{}{},
+		"REMOTE_HOST_HEADERS": []interface{}{"REMOTE_ADDR", "REMOTE_HOST"},
+	}
+}
+
+func (f *fakeAWX) handleSettings(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, f.settings)
+	case http.MethodOptions:
+		fields := make(map[string]interface{})
+		for k, v := range fakeSettingDefaults() {
+			fields[k] = map[string]interface{}{"default": v}
+		}
+		writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+			"actions": map[string]interface{}{"PUT": fields},
+		})
+	case http.MethodPut, http.MethodPatch:
+		for k, v := range body {
+			f.settings[k] = v
+		}
+		writeFakeJSON(w, http.StatusOK, f.settings)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeAWX) handleCollection(w http.ResponseWriter, r *http.Request, collection string, body map[string]interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, fakeList(f.filter(collection, r)))
+	case http.MethodPost:
+		writeFakeJSON(w, http.StatusCreated, f.create(collection, body))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeAWX) handleObject(w http.ResponseWriter, r *http.Request, collection, rawID string, body map[string]interface{}) {
+	id, err := strconv.Atoi(rawID)
+	obj, ok := f.collections[collection][id]
+	if err != nil || !ok {
+		writeFakeNotFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, obj)
+	case http.MethodPut, http.MethodPatch:
+		for k, v := range fakeNormalize(body) {
+			obj[k] = v
+		}
+		obj["modified"] = time.Now().UTC().Format(time.RFC3339)
+		writeFakeJSON(w, http.StatusOK, obj)
+	case http.MethodDelete:
+		delete(f.collections[collection], id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeAWX) handleRelated(w http.ResponseWriter, r *http.Request, collection, rawID, sub string, body map[string]interface{}) {
+	id, err := strconv.Atoi(rawID)
+	parent, ok := f.collections[collection][id]
+	if err != nil || !ok {
+		writeFakeNotFound(w)
+		return
+	}
+	key := fmt.Sprintf("%s/%d/%s", collection, id, sub)
+	target := fakeRelatedCollection(sub)
+
+	switch {
+	case sub == "launch" && r.Method == http.MethodPost:
+		job := f.create("jobs", body)
+		job["job_template"] = float64(id)
+		job["name"] = parent["name"]
+		job["status"] = "successful"
+		job["failed"] = false
+		job["job"] = job["id"]
+		writeFakeJSON(w, http.StatusCreated, job)
+	case sub == "cancel" && r.Method == http.MethodPost:
+		parent["status"] = "canceled"
+		w.WriteHeader(http.StatusAccepted)
+	case sub == "cancel":
+		switch parent["status"] {
+		case "new", "pending", "waiting", "running":
+			writeFakeJSON(w, http.StatusOK, map[string]interface{}{"can_cancel": true})
+		default:
+			writeFakeJSON(w, http.StatusOK, map[string]interface{}{"can_cancel": false})
+		}
+	case sub == "create_approval_template" && r.Method == http.MethodPost:
+		template := f.create("workflow_approval_templates", body)
+		parent["unified_job_template"] = template["id"]
+		parent["summary_fields"].(map[string]interface{})["unified_job_template"] = map[string]interface{}{
+			"id":               template["id"],
+			"name":             template["name"],
+			"unified_job_type": "workflow_approval",
+		}
+		writeFakeJSON(w, http.StatusCreated, template)
+	case sub == "survey_spec" && r.Method == http.MethodGet:
+		survey, ok := f.surveys[key]
+		if !ok {
+			survey = map[string]interface{}{}
+		}
+		writeFakeJSON(w, http.StatusOK, survey)
+	case sub == "survey_spec" && r.Method == http.MethodPost:
+		f.surveys[key] = body
+		w.WriteHeader(http.StatusOK)
+	case sub == "survey_spec" && r.Method == http.MethodDelete:
+		delete(f.surveys, key)
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet:
+		var results []map[string]interface{}
+		for _, rid := range f.related[key] {
+			if obj, ok := f.collections[target][rid]; ok && f.match(obj, r.URL.Query()) {
+				results = append(results, obj)
+			}
+		}
+		writeFakeJSON(w, http.StatusOK, fakeList(results))
+	case r.Method == http.MethodPost && body["id"] != nil:
+		rid := int(fakeNumber(body["id"]))
+		if _, ok := body["disassociate"]; ok {
+			f.related[key] = fakeRemoveID(f.related[key], rid)
+		} else if !fakeContainsID(f.related[key], rid) {
+			f.related[key] = append(f.related[key], rid)
+		}
+		f.syncEdges(parent, key, sub)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost:
+		obj := f.create(target, body)
+		switch sub {
+		case "success_nodes", "failure_nodes", "always_nodes":
+			// child nodes belong to the workflow of their parent
+			obj["workflow_job_template"] = parent["workflow_job_template"]
+		}
+		f.related[key] = append(f.related[key], int(obj["id"].(float64)))
+		f.syncEdges(parent, key, sub)
+		writeFakeJSON(w, http.StatusCreated, obj)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// syncEdges mirrors the children of a workflow node into its success_nodes,
+// failure_nodes and always_nodes fields like AWX does.
+func (f *fakeAWX) syncEdges(parent map[string]interface{}, key, sub string) {
+	switch sub {
+	case "success_nodes", "failure_nodes", "always_nodes":
+		ids := []interface{}{}
+		for _, id := range f.related[key] {
+			ids = append(ids, float64(id))
+		}
+		parent[sub] = ids
+	}
+}
+
+func (f *fakeAWX) create(collection string, body map[string]interface{}) map[string]interface{} {
+	f.lastID++
+	now := time.Now().UTC().Format(time.RFC3339)
+	obj := fakeNormalize(body)
+	obj["id"] = float64(f.lastID)
+	obj["type"] = fakeObjectType(collection)
+	obj["url"] = fmt.Sprintf("/api/v2/%s/%d/", collection, f.lastID)
+	obj["created"] = now
+	obj["modified"] = now
+	if _, ok := obj["summary_fields"]; !ok {
+		obj["summary_fields"] = map[string]interface{}{}
+	}
+	if f.collections[collection] == nil {
+		f.collections[collection] = make(map[int]map[string]interface{})
+	}
+	f.collections[collection][f.lastID] = obj
+
+	if collection == "job_templates" && obj["organization"] == nil {
+		// AWX derives the organization of a job template from its project.
+		if project, ok := f.collections["projects"][int(fakeNumber(obj["project"]))]; ok {
+			obj["organization"] = project["organization"]
+		}
+	}
+
+	summary := obj["summary_fields"].(map[string]interface{})
+	if fakeRoleCollections[collection] {
+		roles := make(map[string]interface{})
+		for field, name := range fakeObjectRoles {
+			role := f.create("roles", map[string]interface{}{
+				"name":        name,
+				"description": "",
+			})
+			roles[field] = map[string]interface{}{
+				"id":          role["id"],
+				"name":        name,
+				"description": "",
+			}
+		}
+		summary["object_roles"] = roles
+	}
+	if collection == "projects" {
+		update := f.create("project_updates", map[string]interface{}{
+			"project":  obj["id"],
+			"status":   "successful",
+			"failed":   false,
+			"finished": now,
+		})
+		obj["status"] = "successful"
+		summary["last_job"] = map[string]interface{}{
+			"id":     update["id"],
+			"status": "successful",
+		}
+	}
+	return obj
+}
+
+// filter returns the objects of a collection matching the query parameters
+// of the request, ignoring pagination and ordering.
+func (f *fakeAWX) filter(collection string, r *http.Request) []map[string]interface{} {
+	var ids []int
+	for id := range f.collections[collection] {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var results []map[string]interface{}
+	for _, id := range ids {
+		if obj := f.collections[collection][id]; f.match(obj, r.URL.Query()) {
+			results = append(results, obj)
+		}
+	}
+	return results
+}
+
+// match reports whether an object matches the field filters of a query.
+func (f *fakeAWX) match(obj map[string]interface{}, query url.Values) bool {
+	for k, v := range query {
+		switch k {
+		case "page", "page_size", "order_by", "search":
+			continue
+		}
+		field := strings.TrimSuffix(strings.TrimSuffix(k, "__iexact"), "__exact")
+		if fakeString(f.lookup(obj, field)) != v[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// lookup returns the value of a field filter like
+// inventory__organization__name by following the foreign keys.
+func (f *fakeAWX) lookup(obj map[string]interface{}, field string) interface{} {
+	parts := strings.SplitN(field, "__", 2)
+	if len(parts) == 1 {
+		return obj[field]
+	}
+	related, ok := f.collections[fakeCollection(parts[0])][int(fakeNumber(obj[parts[0]]))]
+	if !ok {
+		return nil
+	}
+	return f.lookup(related, parts[1])
+}
+
+func fakeCollection(objectType string) string {
+	switch objectType {
+	case "source_credential", "target_credential", "webhook_credential":
+		return "credentials"
+	case "source_project":
+		return "projects"
+	}
+	if strings.HasSuffix(objectType, "y") {
+		return strings.TrimSuffix(objectType, "y") + "ies"
+	}
+	return objectType + "s"
+}
+
+func fakeObjectType(collection string) string {
+	if strings.HasSuffix(collection, "ies") {
+		return strings.TrimSuffix(collection, "ies") + "y"
+	}
+	return strings.TrimSuffix(collection, "s")
+}
+
+func fakeRelatedCollection(sub string) string {
+	switch sub {
+	case "success_nodes", "failure_nodes", "always_nodes", "workflow_nodes":
+		return "workflow_job_template_nodes"
+	}
+	if strings.HasPrefix(sub, "notification_templates_") {
+		return "notification_templates"
+	}
+	return sub
+}
+
+func fakeNormalize(body map[string]interface{}) map[string]interface{} {
+	obj := make(map[string]interface{}, len(body))
+	for k, v := range body {
+		obj[k] = v
+	}
+	for _, k := range fakeForeignKeys {
+		switch v := obj[k].(type) {
+		case string:
+			if n, err := strconv.Atoi(v); err == nil {
+				obj[k] = float64(n)
+			} else if v == "" {
+				obj[k] = nil
+			}
+		case float64:
+			if v == 0 {
+				obj[k] = nil
+			}
+		}
+	}
+	return obj
+}
+
+func fakeList(results []map[string]interface{}) map[string]interface{} {
+	if results == nil {
+		results = []map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"count":    len(results),
+		"next":     nil,
+		"previous": nil,
+		"results":  results,
+	}
+}
+
+func fakeString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func fakeNumber(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case string:
+		f, _ := strconv.ParseFloat(n, 64)
+		return f
+	}
+	return 0
+}
+
+func fakeContainsID(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func fakeRemoveID(ids []int, id int) []int {
+	var result []int
+	for _, v := range ids {
+		if v != id {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func writeFakeNotFound(w http.ResponseWriter) {
+	writeFakeJSON(w, http.StatusNotFound, map[string]interface{}{"detail": "Not found."})
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-API-Request-Id", strconv.FormatInt(time.Now().UnixNano(), 36))
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
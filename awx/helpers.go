@@ -89,6 +89,41 @@ func convertStateIDToNummeric(tfElement string, d *schema.ResourceData) (int, di
 	return id, diags
 }
 
+// optionalID returns id for a foreign key payload field, or nil to clear
+// the field when id is not set.
+func optionalID(id int) interface{} {
+	if id > 0 {
+		return id
+	}
+	return nil
+}
+
+// customizeDiffConfiguredEmpty plans an optional and computed collection
+// configured as empty, like labels = [], as empty. The SDK does not tell an
+// empty collection from a missing one and would keep the current value.
+func customizeDiffConfiguredEmpty(key string, empty interface{}) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		config := d.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+		if v := config.GetAttr(key); v.IsNull() || !v.IsKnown() || v.LengthInt() > 0 {
+			return nil
+		}
+		switch v := d.Get(key).(type) {
+		case *schema.Set:
+			if v.Len() == 0 {
+				return nil
+			}
+		case []interface{}:
+			if len(v) == 0 {
+				return nil
+			}
+		}
+		return d.SetNew(key, empty)
+	}
+}
+
 func buildDiagnosticsMessage(diagSummary, diagDetails string, detailsVars ...interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	diags = append(diags, diag.Diagnostic{
@@ -0,0 +1,54 @@
+package awx
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// newHTTPTransport builds a dedicated transport for the AWX API from the
+// TLS settings of the provider block. http.DefaultTransport is cloned and
+// never modified, so other users of the default client are not affected.
+func newHTTPTransport(d *schema.ResourceData) (*http.Transport, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: d.Get("insecure").(bool),
+		ServerName:         d.Get("tls_server_name").(string),
+	}
+
+	caCert := []byte(d.Get("ca_cert_pem").(string))
+	if caFile := d.Get("ca_cert_file").(string); caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca_cert_file %s: %s", caFile, err)
+		}
+		caCert = pem
+	}
+	if len(caCert) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no valid PEM encoded certificate found in CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	clientCert := d.Get("client_cert").(string)
+	clientKey := d.Get("client_key").(string)
+	if clientCert != "" || clientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
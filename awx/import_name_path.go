@@ -0,0 +1,177 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// namePath describes how a human readable import ID like
+// Default/my-inventory/web01 is resolved to the numeric ID of an object.
+// Every segment of the path is matched by one filter of the list endpoint,
+// from the outermost scope to the name of the object itself. The last segment
+// takes the rest of the path, so the name of the object may contain "/".
+type namePath struct {
+	endpoint string
+	format   string
+	filters  []string
+}
+
+var (
+	namePathOrganization = namePath{
+		endpoint: "/api/v2/organizations/",
+		format:   "<name>",
+		filters:  []string{"name"},
+	}
+	namePathCredentialType = namePath{
+		endpoint: "/api/v2/credential_types/",
+		format:   "<name>",
+		filters:  []string{"name"},
+	}
+	namePathSchedule = namePath{
+		endpoint: "/api/v2/schedules/",
+		format:   "<template>/<name>",
+		filters:  []string{"unified_job_template__name", "name"},
+	}
+	namePathExecutionEnvironment = namePath{
+		endpoint: "/api/v2/execution_environments/",
+		format:   "<name>",
+		filters:  []string{"name"},
+	}
+	namePathInstanceGroup = namePath{
+		endpoint: "/api/v2/instance_groups/",
+		format:   "<name>",
+		filters:  []string{"name"},
+	}
+	namePathUser = namePath{
+		endpoint: "/api/v2/users/",
+		format:   "<username>",
+		filters:  []string{"username"},
+	}
+	namePathInventory            = organizationNamePath("/api/v2/inventories/")
+	namePathProject              = organizationNamePath("/api/v2/projects/")
+	namePathTeam                 = organizationNamePath("/api/v2/teams/")
+	namePathCredential           = organizationNamePath("/api/v2/credentials/")
+	namePathJobTemplate          = organizationNamePath("/api/v2/job_templates/")
+	namePathLabel                = organizationNamePath("/api/v2/labels/")
+	namePathNotificationTemplate = organizationNamePath("/api/v2/notification_templates/")
+	namePathWorkflowJobTemplate  = organizationNamePath("/api/v2/workflow_job_templates/")
+	namePathHost                 = inventoryNamePath("/api/v2/hosts/")
+	namePathInventoryGroup       = inventoryNamePath("/api/v2/groups/")
+	namePathInventorySource      = inventoryNamePath("/api/v2/inventory_sources/")
+
+	namePathWorkflowJobTemplateNode = namePath{
+		endpoint: "/api/v2/workflow_job_template_nodes/",
+		format:   "<organization>/<workflow>/<identifier>",
+		filters:  []string{"workflow_job_template__organization__name", "workflow_job_template__name", "identifier"},
+	}
+	namePathCredentialInputSource = namePath{
+		endpoint: "/api/v2/credential_input_sources/",
+		format:   "<organization>/<credential>/<input_field_name>",
+		filters:  []string{"target_credential__organization__name", "target_credential__name", "input_field_name"},
+	}
+)
+
+func organizationNamePath(endpoint string) namePath {
+	return namePath{
+		endpoint: endpoint,
+		format:   "<organization>/<name>",
+		filters:  []string{"organization__name", "name"},
+	}
+}
+
+func inventoryNamePath(endpoint string) namePath {
+	return namePath{
+		endpoint: endpoint,
+		format:   "<organization>/<inventory>/<name>",
+		filters:  []string{"inventory__organization__name", "inventory__name", "name"},
+	}
+}
+
+// importStateByNamePath returns an importer which accepts the numeric ID of
+// an object as well as its name path. The resolved numeric ID is stored in
+// the state.
+func importStateByNamePath(p namePath) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		if _, err := strconv.Atoi(d.Id()); err == nil {
+			return []*schema.ResourceData{d}, nil
+		}
+		id, err := p.resolve(context.Background(), getAPIClient(m), d.Id())
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(strconv.Itoa(id))
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// importIDPart is one part of the composite ID of an association resource.
+// A part with a name path accepts the name path of the object as well as its
+// numeric ID.
+type importIDPart struct {
+	name string
+	path *namePath
+}
+
+// splitImportID splits the composite ID of an association resource, like
+// <job_template>:<credential>, into the numeric IDs of its parts. Only the
+// last part may contain a colon.
+func splitImportID(m interface{}, id string, parts ...importIDPart) ([]int, error) {
+	format := make([]string, len(parts))
+	for i, part := range parts {
+		format[i] = part.name
+	}
+	values := strings.SplitN(id, ":", len(parts))
+	if len(values) != len(parts) {
+		return nil, fmt.Errorf("unexpected format of ID %q, expected %s", id, strings.Join(format, ":"))
+	}
+
+	ids := make([]int, len(values))
+	for i, v := range values {
+		n, err := strconv.Atoi(v)
+		switch {
+		case err == nil:
+			ids[i] = n
+		case parts[i].path != nil:
+			if ids[i], err = parts[i].path.resolve(context.Background(), getAPIClient(m), v); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("%s in ID %q is not numeric", parts[i].name, id)
+		}
+	}
+	return ids, nil
+}
+
+func (p namePath) resolve(ctx context.Context, a *apiClient, path string) (int, error) {
+	names := strings.SplitN(path, "/", len(p.filters))
+	if len(names) != len(p.filters) {
+		return 0, fmt.Errorf("unexpected format of ID %q, expected a numeric ID or %s", path, p.format)
+	}
+	params := make(map[string]string)
+	for i, filter := range p.filters {
+		if names[i] == "" {
+			return 0, fmt.Errorf("unexpected format of ID %q, expected a numeric ID or %s", path, p.format)
+		}
+		params[filter] = names[i]
+	}
+
+	results, err := a.list(ctx, p.endpoint, params)
+	if err != nil {
+		return 0, fmt.Errorf("unable to resolve %q: %s", path, err)
+	}
+	switch len(results) {
+	case 0:
+		return 0, fmt.Errorf("no object found for %q", path)
+	case 1:
+		id, ok := results[0]["id"].(float64)
+		if !ok {
+			return 0, fmt.Errorf("object found for %q has no numeric ID", path)
+		}
+		return int(id), nil
+	}
+	return 0, fmt.Errorf("%d objects found for %q, use the numeric ID instead", len(results), path)
+}
@@ -2,11 +2,14 @@ package awx
 
 import (
 	"context"
-	"crypto/tls"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	awx "github.com/mrcrilly/goawx/client"
 )
 
@@ -24,43 +27,166 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: "Disable SSL verification of API calls",
 			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_pem"},
+				Description:   "Path to a PEM encoded CA bundle used to verify the AWX server certificate",
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "PEM encoded CA bundle used to verify the AWX server certificate",
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key"},
+				Description:  "PEM encoded client certificate for mutual TLS",
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"client_cert"},
+				Description:  "PEM encoded private key of the client certificate",
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Server name used to verify the AWX server certificate, if it differs from the hostname",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of retries for API requests failing with a transient error",
+			},
+			"retry_min_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Minimum time in seconds to wait before retrying a request",
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum time in seconds to wait before retrying a request",
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of API requests per second, 0 disables the limit",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of API requests in flight, 0 disables the limit",
+			},
 			"username": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("AWX_USERNAME", "admin"),
+				DefaultFunc: schema.EnvDefaultFunc("AWX_USERNAME", nil),
+				Description: "Username for basic authentication",
 			},
 			"password": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("AWX_PASSWORD", "password"),
+				DefaultFunc: schema.EnvDefaultFunc("AWX_PASSWORD", nil),
+				Description: "Password for basic authentication",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_TOKEN", nil),
+				Description: "Personal access token sent as bearer token",
+			},
+			"oauth2": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "OAuth2 client credentials used to fetch a bearer token from /api/o/token/",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Client ID of the AWX OAuth2 application",
+						},
+						"client_secret": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "Client secret of the AWX OAuth2 application",
+						},
+						"scope": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "write",
+							Description: "One of read or write",
+						},
+					},
+				},
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"awx_credential_azure_key_vault":         resourceCredentialAzureKeyVault(),
-			"awx_credential_google_compute_engine":   resourceCredentialGoogleComputeEngine(),
-			"awx_credential_input_source":            resourceCredentialInputSource(),
-			"awx_credential":                         resourceCredential(),
-			"awx_credential_type":                    resourceCredentialType(),
-			"awx_credential_machine":                 resourceCredentialMachine(),
-			"awx_credential_scm":                     resourceCredentialSCM(),
-			"awx_host":                               resourceHost(),
-			"awx_inventory_group":                    resourceInventoryGroup(),
-			"awx_inventory_source":                   resourceInventorySource(),
-			"awx_inventory":                          resourceInventory(),
-			"awx_job_template_credential":            resourceJobTemplateCredentials(),
-			"awx_job_template":                       resourceJobTemplate(),
-			"awx_job_template_launch":                resourceJobTemplateLaunch(),
-			"awx_organization":                       resourceOrganization(),
-			"awx_project":                            resourceProject(),
-			"awx_settings_ldap_team_map":             resourceSettingsLDAPTeamMap(),
-			"awx_setting":                            resourceSetting(),
-			"awx_team":                               resourceTeam(),
-			"awx_workflow_job_template_node_allways": resourceWorkflowJobTemplateNodeAllways(),
-			"awx_workflow_job_template_node_failure": resourceWorkflowJobTemplateNodeFailure(),
-			"awx_workflow_job_template_node_success": resourceWorkflowJobTemplateNodeSuccess(),
-			"awx_workflow_job_template_node":         resourceWorkflowJobTemplateNode(),
-			"awx_workflow_job_template":              resourceWorkflowJobTemplate(),
+			"awx_credential_ansible_automation_platform": resourceCredentialAnsibleAutomationPlatform(),
+			"awx_credential_aws":                         resourceCredentialAWS(),
+			"awx_credential_azure_key_vault":             resourceCredentialAzureKeyVault(),
+			"awx_credential_container_registry":          resourceCredentialContainerRegistry(),
+			"awx_credential_cyberark_ccp":                resourceCredentialCyberArkCCP(),
+			"awx_credential_galaxy_api_token":            resourceCredentialGalaxyAPIToken(),
+			"awx_credential_google_compute_engine":       resourceCredentialGoogleComputeEngine(),
+			"awx_credential_hashicorp_vault_lookup":      resourceCredentialHashiCorpVaultLookup(),
+			"awx_credential_hashicorp_vault_ssh":         resourceCredentialHashiCorpVaultSSH(),
+			"awx_credential_input_source":                resourceCredentialInputSource(),
+			"awx_credential_insights":                    resourceCredentialInsights(),
+			"awx_credential_kubernetes_bearer_token":     resourceCredentialKubernetesBearerToken(),
+			"awx_credential":                             resourceCredential(),
+			"awx_credential_type":                        resourceCredentialType(),
+			"awx_credential_machine":                     resourceCredentialMachine(),
+			"awx_credential_network":                     resourceCredentialNetwork(),
+			"awx_credential_scm":                         resourceCredentialSCM(),
+			"awx_credential_thycotic_dsv":                resourceCredentialThycoticDSV(),
+			"awx_credential_thycotic_secret_server":      resourceCredentialThycoticSecretServer(),
+			"awx_credential_vault":                       resourceCredentialVault(),
+			"awx_execution_environment":                  resourceExecutionEnvironment(),
+			"awx_host":                                   resourceHost(),
+			"awx_instance_group":                         resourceInstanceGroup(),
+			"awx_inventory_group":                        resourceInventoryGroup(),
+			"awx_inventory_source":                       resourceInventorySource(),
+			"awx_inventory":                              resourceInventory(),
+			"awx_job_template_credential":                resourceJobTemplateCredentials(),
+			"awx_job_template":                           resourceJobTemplate(),
+			"awx_job_template_launch":                    resourceJobTemplateLaunch(),
+			"awx_label":                                  resourceLabel(),
+			"awx_notification_template":                  resourceNotificationTemplate(),
+			"awx_notification_template_association":      resourceNotificationTemplateAssociation(),
+			"awx_organization":                           resourceOrganization(),
+			"awx_project":                                resourceProject(),
+			"awx_schedule":                               resourceSchedule(),
+			"awx_settings_ldap_team_map":                 resourceSettingsLDAPTeamMap(),
+			"awx_setting":                                resourceSetting(),
+			"awx_team":                                   resourceTeam(),
+			"awx_user":                                   resourceUser(),
+			"awx_user_role":                              resourceUserRole(),
+			"awx_workflow_job_template_graph":            resourceWorkflowJobTemplateGraph(),
+			"awx_workflow_job_template_node_allways":     resourceWorkflowJobTemplateNodeAllways(),
+			"awx_workflow_job_template_node_failure":     resourceWorkflowJobTemplateNodeFailure(),
+			"awx_workflow_job_template_node_success":     resourceWorkflowJobTemplateNodeSuccess(),
+			"awx_workflow_job_template_node":             resourceWorkflowJobTemplateNode(),
+			"awx_workflow_job_template":                  resourceWorkflowJobTemplate(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"awx_credential_azure_key_vault": dataSourceCredentialAzure(),
@@ -78,6 +204,8 @@ func Provider() *schema.Provider {
 			"awx_project_role":               dataSourceProjectRole(),
 			"awx_workflow_job_template":      dataSourceWorkflowJobTemplate(),
 			"awx_team":                       dataSourceTeam(),
+			"awx_team_role":                  dataSourceTeamRole(),
+			"awx_user":                       dataSourceUser(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -87,13 +215,72 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	hostname := d.Get("hostname").(string)
 	username := d.Get("username").(string)
 	password := d.Get("password").(string)
+	token := d.Get("token").(string)
+	oauth2 := d.Get("oauth2").([]interface{})
 
-	client := http.DefaultClient
-	if d.Get("insecure").(bool) {
-		client.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	var authMethods []string
+	if username != "" || password != "" {
+		authMethods = append(authMethods, authMethodBasic)
+	}
+	if token != "" {
+		authMethods = append(authMethods, authMethodToken)
+	}
+	if len(oauth2) > 0 {
+		authMethods = append(authMethods, authMethodOAuth2)
+	}
+	if len(authMethods) != 1 {
+		return nil, buildDiagnosticsMessage(
+			"Invalid provider authentication",
+			"Exactly one of username/password, token or oauth2 must be configured, got: [%s]",
+			strings.Join(authMethods, ", "),
+		)
+	}
+	authMethod := authMethods[0]
+
+	transport, err := newHTTPTransport(d)
+	if err != nil {
+		return nil, buildDiagnosticsMessage(
+			"Invalid provider TLS configuration",
+			"Unable to configure TLS for the AWX API: %s", err.Error(),
+		)
+	}
+
+	auth := &authTransport{base: transport}
+	switch authMethod {
+	case authMethodBasic:
+		if username == "" || password == "" {
+			return nil, buildDiagnosticsMessage(
+				"Invalid provider authentication",
+				"Basic authentication requires both username and password",
+			)
+		}
+		auth.username = username
+		auth.password = password
+	case authMethodToken:
+		auth.tokens = staticTokenSource(token)
+	case authMethodOAuth2:
+		o := oauth2[0].(map[string]interface{})
+		auth.tokens = &oauth2ClientCredentialsSource{
+			tokenURL:     strings.TrimRight(hostname, "/") + "/api/o/token/",
+			clientID:     o["client_id"].(string),
+			clientSecret: o["client_secret"].(string),
+			scope:        o["scope"].(string),
+			client:       &http.Client{Transport: transport},
 		}
 	}
+	limited := newRateLimitTransport(
+		&loggingTransport{base: auth},
+		d.Get("requests_per_second").(float64),
+		d.Get("max_concurrent_requests").(int),
+	)
+	client := &http.Client{
+		Transport: &retryTransport{
+			base:       limited,
+			maxRetries: d.Get("max_retries").(int),
+			minWait:    time.Duration(d.Get("retry_min_wait").(int)) * time.Second,
+			maxWait:    time.Duration(d.Get("retry_max_wait").(int)) * time.Second,
+		},
+	}
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -102,10 +289,21 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create AWX client",
-			Detail:   "Unable to auth user against AWX API: check the hostname, username and password",
+			Detail:   fmt.Sprintf("Unable to connect to AWX API at %s: %s", hostname, err.Error()),
+		})
+		return nil, diags
+	}
+
+	api := newAPIClient(hostname, client)
+	if err := checkAuthentication(ctx, api); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create AWX client",
+			Detail:   fmt.Sprintf("Unable to authenticate against AWX API using %s authentication: %s", authMethod, err.Error()),
 		})
 		return nil, diags
 	}
+	registerAPIClient(c, api)
 
 	return c, diags
 }
@@ -1,10 +1,35 @@
 /*
-*TBD*
+`awx_credential` manages credentials of any credential type, including the
+custom credential types of `awx_credential_type`. The inputs are validated at
+plan time against the fields of the credential type once its ID is known:
+required fields, field types, allowed choices and unknown inputs are reported
+without showing the values of secret fields.
 
 # Example Usage
 
 ```hcl
-*TBD*
+
+	resource "awx_credential" "api" {
+	  name               = "api"
+	  organization_id    = awx_organization.default.id
+	  credential_type_id = awx_credential_type.api.id
+	  inputs = jsonencode({
+	    token  = var.api_token
+	    region = "eu"
+	  })
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential.example 7
+terraform import awx_credential.example Default/my-credential
 ```
 */
 package awx
@@ -13,7 +38,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -45,12 +72,136 @@ func resourceCredential() *schema.Resource {
 				Description: "Specify the type of credential you want to create. Refer to the Ansible Tower documentation for details on each type",
 			},
 			"inputs": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "JSON encoded inputs, validated against the fields of the credential type",
 			},
 		},
+		CustomizeDiff: customizeDiffCredentialInputs,
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathCredential),
+		},
+	}
+}
+
+// awxCredentialTypeField is a field of the inputs of a credential type.
+type awxCredentialTypeField struct {
+	ID        string        `json:"id"`
+	Label     string        `json:"label"`
+	Type      string        `json:"type"`
+	Secret    bool          `json:"secret"`
+	Multiline bool          `json:"multiline"`
+	Choices   []interface{} `json:"choices"`
+	HelpText  string        `json:"help_text"`
+	Format    string        `json:"format"`
+}
+
+// awxCredentialTypeInputs are the inputs of a credential type.
+type awxCredentialTypeInputs struct {
+	Fields   []awxCredentialTypeField `json:"fields"`
+	Required []string                 `json:"required"`
+}
+
+// customizeDiffCredentialInputs validates the inputs against the credential
+// type, once both are known.
+func customizeDiffCredentialInputs(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("inputs") && !d.HasChange("credential_type_id") {
+		return nil
+	}
+	if !d.NewValueKnown("inputs") || !d.NewValueKnown("credential_type_id") {
+		return nil
+	}
+
+	var inputs map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("inputs").(string)), &inputs); err != nil {
+		return fmt.Errorf("inputs must be a JSON object")
+	}
+
+	typeID := d.Get("credential_type_id").(int)
+	var credentialType struct {
+		Name   string                  `json:"name"`
+		Inputs awxCredentialTypeInputs `json:"inputs"`
+	}
+	if err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/credential_types/%d/", typeID), nil, &credentialType); err != nil {
+		return fmt.Errorf("unable to read the credential type %d, %s", typeID, err)
+	}
+	return validateCredentialInputs(credentialType.Name, credentialType.Inputs, inputs)
+}
+
+// validateCredentialInputs validates the inputs of a credential against the
+// fields of its credential type. Values of secret fields are never part of
+// the error.
+func validateCredentialInputs(typeName string, definition awxCredentialTypeInputs, inputs map[string]interface{}) error {
+	var problems []string
+	fields := make(map[string]bool, len(definition.Fields))
+	for _, field := range definition.Fields {
+		fields[field.ID] = true
+		value, ok := inputs[field.ID]
+		if !ok || value == nil {
+			continue
+		}
+		describe := fmt.Sprintf("%q", value)
+		if field.Secret {
+			describe = "the value"
+		}
+
+		switch field.Type {
+		case "boolean":
+			if _, ok := value.(bool); !ok {
+				problems = append(problems, fmt.Sprintf("%s must be a boolean", field.ID))
+			}
+			continue
+		case "", "string":
+			if _, ok := value.(string); !ok {
+				problems = append(problems, fmt.Sprintf("%s must be a string", field.ID))
+				continue
+			}
+		}
+		if len(field.Choices) > 0 && !credentialInputChoice(field.Choices, value) {
+			choices := make([]string, len(field.Choices))
+			for i, choice := range field.Choices {
+				choices[i] = fmt.Sprint(choice)
+			}
+			problems = append(problems, fmt.Sprintf("%s must be one of %s, got %s", field.ID, strings.Join(choices, ", "), describe))
+		}
+		if field.Format == "ssh_private_key" {
+			if _, errs := validateCredentialPrivateKey(value, field.ID); len(errs) > 0 {
+				problems = append(problems, fmt.Sprintf("%s must be a PEM encoded private key", field.ID))
+			}
+		}
+	}
+
+	for _, id := range definition.Required {
+		if value, ok := inputs[id]; !ok || value == nil || value == "" {
+			problems = append(problems, fmt.Sprintf("%s is required", id))
+		}
+	}
+
+	var unknown []string
+	for id := range inputs {
+		if !fields[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	sort.Strings(unknown)
+	for _, id := range unknown {
+		problems = append(problems, fmt.Sprintf("%s is not an input of the credential type", id))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid inputs for the %s credential type: %s", typeName, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func credentialInputChoice(choices []interface{}, value interface{}) bool {
+	for _, choice := range choices {
+		if choice == value {
+			return true
+		}
 	}
+	return false
 }
 
 func resourceCredentialCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -113,6 +264,7 @@ func resourceCredentialRead(ctx context.Context, d *schema.ResourceData, m inter
 	d.Set("name", cred.Name)
 	d.Set("description", cred.Description)
 	d.Set("organization_id", cred.OrganizationID)
+	d.Set("credential_type_id", cred.CredentialTypeID)
 	d.Set("inputs", cred.Inputs)
 
 	return diags
@@ -125,6 +277,7 @@ func resourceCredentialUpdate(ctx context.Context, d *schema.ResourceData, m int
 		"name",
 		"description",
 		"organization_id",
+		"credential_type_id",
 		"inputs",
 	}
 
@@ -165,5 +318,5 @@ func resourceCredentialUpdate(ctx context.Context, d *schema.ResourceData, m int
 		}
 	}
 
-	return resourceCredentialSCMRead(ctx, d, m)
+	return resourceCredentialRead(ctx, d, m)
 }
@@ -0,0 +1,50 @@
+/*
+`awx_credential_ansible_automation_platform` manages Red Hat Ansible Automation Platform credentials, used by playbooks talking to another AWX or automation controller.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_ansible_automation_platform" "controller" {
+	  name            = "controller"
+	  organization_id = awx_organization.default.id
+	  host            = "https://controller.example.com"
+	  oauth_token     = var.controller_token
+	  verify_ssl      = true
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_ansible_automation_platform.controller 7
+terraform import awx_credential_ansible_automation_platform.controller Default/my-credential
+```
+*/
+package awx
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var credentialKindAnsibleAutomationPlatform = &credentialKind{
+	name:      "Red Hat Ansible Automation Platform",
+	namespace: "controller",
+	inputs: []credentialInput{
+		{attribute: "host", required: true, validate: validation.IsURLWithHTTPorHTTPS, description: "URL of the automation platform"},
+		{attribute: "username", conflictsWith: []string{"oauth_token"}, description: "Username used to log in"},
+		{attribute: "password", secret: true, requiredWith: []string{"username"}, conflictsWith: []string{"oauth_token"}, description: "Password used to log in"},
+		{attribute: "oauth_token", secret: true, description: "OAuth token used instead of username and password"},
+		{attribute: "verify_ssl", boolean: true, description: "Verify the TLS certificate of the automation platform"},
+	},
+}
+
+func resourceCredentialAnsibleAutomationPlatform() *schema.Resource {
+	return resourceCredentialKind(credentialKindAnsibleAutomationPlatform)
+}
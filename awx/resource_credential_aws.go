@@ -0,0 +1,46 @@
+/*
+`awx_credential_aws` manages Amazon Web Services credentials, used by inventory sources and playbooks talking to AWS.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_aws" "deploy" {
+	  name            = "aws-deploy"
+	  organization_id = awx_organization.default.id
+	  access_key      = var.aws_access_key_id
+	  secret_key      = var.aws_secret_access_key
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_aws.deploy 7
+terraform import awx_credential_aws.deploy Default/my-credential
+```
+*/
+package awx
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var credentialKindAWS = &credentialKind{
+	name:      "Amazon Web Services",
+	namespace: "aws",
+	inputs: []credentialInput{
+		{attribute: "access_key", id: "username", required: true, description: "Access key ID"},
+		{attribute: "secret_key", id: "password", required: true, secret: true, description: "Secret access key"},
+		{attribute: "security_token", secret: true, description: "Session token of temporary credentials issued by STS"},
+	},
+}
+
+func resourceCredentialAWS() *schema.Resource {
+	return resourceCredentialKind(credentialKindAWS)
+}
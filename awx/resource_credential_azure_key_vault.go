@@ -6,6 +6,17 @@
 ```hcl
 *TBD*
 ```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_azure_key_vault.example 7
+terraform import awx_credential_azure_key_vault.example Default/my-credential
+```
 */
 package awx
 
@@ -56,6 +67,9 @@ func resourceCredentialAzureKeyVault() *schema.Resource {
 				Required: true,
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathCredential),
+		},
 	}
 }
 
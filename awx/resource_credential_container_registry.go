@@ -0,0 +1,49 @@
+/*
+`awx_credential_container_registry` manages Container Registry credentials, used to pull the images of execution environments.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_container_registry" "quay" {
+	  name            = "quay"
+	  organization_id = awx_organization.default.id
+	  host            = "quay.io"
+	  username        = "robot+awx"
+	  password        = var.quay_token
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_container_registry.quay 7
+terraform import awx_credential_container_registry.quay Default/my-credential
+```
+*/
+package awx
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var credentialKindContainerRegistry = &credentialKind{
+	name:      "Container Registry",
+	namespace: "registry",
+	inputs: []credentialInput{
+		{attribute: "host", defaultValue: "quay.io", validate: validation.StringIsNotWhiteSpace, description: "Host name of the registry"},
+		{attribute: "username", description: "Username used to log in to the registry"},
+		{attribute: "password", secret: true, requiredWith: []string{"username"}, description: "Password or token used to log in to the registry"},
+		{attribute: "verify_ssl", boolean: true, defaultValue: true, description: "Verify the TLS certificate of the registry"},
+	},
+}
+
+func resourceCredentialContainerRegistry() *schema.Resource {
+	return resourceCredentialKind(credentialKindContainerRegistry)
+}
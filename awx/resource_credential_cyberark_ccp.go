@@ -0,0 +1,73 @@
+/*
+`awx_credential_cyberark_ccp` manages CyberArk Central Credential Provider Lookup credentials, used as source of `awx_credential_input_source` to read secrets from CyberArk.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_cyberark_ccp" "cyberark" {
+	  name            = "cyberark"
+	  organization_id = awx_organization.default.id
+	  url             = "https://ccp.example.com"
+	  app_id          = "awx"
+	  client_cert     = file("${path.module}/client.crt")
+	  client_key      = var.cyberark_client_key
+	}
+
+	resource "awx_credential_input_source" "password" {
+	  input_field_name = "password"
+	  target           = awx_credential_machine.deploy.id
+	  source           = awx_credential_cyberark_ccp.cyberark.id
+
+	  cyberark_ccp {
+	    object_query = "Safe=Deploy;Object=deploy"
+	  }
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_cyberark_ccp.cyberark 7
+terraform import awx_credential_cyberark_ccp.cyberark Default/my-credential
+```
+*/
+package awx
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var credentialKindCyberArkCCP = &credentialKind{
+	name:      "CyberArk Central Credential Provider Lookup",
+	namespace: "aim",
+	inputs: []credentialInput{
+		{attribute: "url", required: true, validate: validation.IsURLWithHTTPorHTTPS, description: "URL of the Central Credential Provider"},
+		{attribute: "webservice_id", description: "Name of the Central Credential Provider web service, AIMWebService if not set"},
+		{attribute: "app_id", required: true, description: "Application ID registered in CyberArk"},
+		{attribute: "client_cert", secret: true, validate: validateCredentialCertificate, requiredWith: []string{"client_key"}, description: "PEM encoded client certificate"},
+		{attribute: "client_key", secret: true, validate: validateCredentialPrivateKey, requiredWith: []string{"client_cert"}, description: "PEM encoded private key of the client certificate"},
+		{attribute: "verify", boolean: true, defaultValue: true, description: "Verify the TLS certificate of the Central Credential Provider"},
+	},
+	metadata: []credentialInput{
+		{attribute: "object_query", required: true, description: "Lookup query of the object, for example Safe=Deploy;Object=deploy"},
+		{
+			attribute:    "object_query_format",
+			defaultValue: "Exact",
+			validate:     validation.StringInSlice([]string{"Exact", "Regexp"}, false),
+			description:  "Format of object_query, one of Exact or Regexp",
+		},
+		{attribute: "object_property", description: "Property of the object to return, the content if not set"},
+		{attribute: "reason", description: "Reason for the lookup, required by some safe policies"},
+	},
+}
+
+func resourceCredentialCyberArkCCP() *schema.Resource {
+	return resourceCredentialKind(credentialKindCyberArkCCP)
+}
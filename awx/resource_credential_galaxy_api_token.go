@@ -0,0 +1,48 @@
+/*
+`awx_credential_galaxy_api_token` manages Ansible Galaxy/Automation Hub API Token credentials, attached to organizations to install collections.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_galaxy_api_token" "hub" {
+	  name            = "automation-hub"
+	  organization_id = awx_organization.default.id
+	  url             = "https://console.redhat.com/api/automation-hub/"
+	  auth_url        = "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token"
+	  token           = var.hub_token
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_galaxy_api_token.hub 7
+terraform import awx_credential_galaxy_api_token.hub Default/my-credential
+```
+*/
+package awx
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var credentialKindGalaxyAPIToken = &credentialKind{
+	name:      "Ansible Galaxy/Automation Hub API Token",
+	namespace: "galaxy_api_token",
+	inputs: []credentialInput{
+		{attribute: "url", required: true, validate: validation.IsURLWithHTTPorHTTPS, description: "URL of the Galaxy server"},
+		{attribute: "auth_url", validate: validation.IsURLWithHTTPorHTTPS, description: "URL of the SSO token endpoint, for Automation Hub"},
+		{attribute: "token", secret: true, description: "API token of the Galaxy server"},
+	},
+}
+
+func resourceCredentialGalaxyAPIToken() *schema.Resource {
+	return resourceCredentialKind(credentialKindGalaxyAPIToken)
+}
@@ -6,6 +6,17 @@
 ```hcl
 *TBD*
 ```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_google_compute_engine.example 7
+terraform import awx_credential_google_compute_engine.example Default/my-credential
+```
 */
 package awx
 
@@ -52,6 +63,9 @@ func resourceCredentialGoogleComputeEngine() *schema.Resource {
 				Sensitive: true,
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathCredential),
+		},
 	}
 }
 
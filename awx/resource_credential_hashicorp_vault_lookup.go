@@ -0,0 +1,91 @@
+/*
+`awx_credential_hashicorp_vault_lookup` manages HashiCorp Vault Secret Lookup credentials, used as source of `awx_credential_input_source` to read secrets from the KV secrets engine.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_hashicorp_vault_lookup" "vault" {
+	  name            = "vault"
+	  organization_id = awx_organization.default.id
+	  url             = "https://vault.example.com:8200"
+	  api_version     = "v2"
+	  role_id         = var.vault_role_id
+	  secret_id       = var.vault_secret_id
+	}
+
+	resource "awx_credential_input_source" "password" {
+	  input_field_name = "password"
+	  target           = awx_credential_machine.deploy.id
+	  source           = awx_credential_hashicorp_vault_lookup.vault.id
+
+	  hashicorp_vault_lookup {
+	    secret_path = "/kv/deploy"
+	    secret_key  = "password"
+	  }
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_hashicorp_vault_lookup.vault 7
+terraform import awx_credential_hashicorp_vault_lookup.vault Default/my-credential
+```
+*/
+package awx
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// hashicorpVaultInputs are the inputs shared by the HashiCorp Vault
+// credential types, they configure how AWX authenticates to Vault.
+var hashicorpVaultInputs = []credentialInput{
+	{attribute: "url", required: true, validate: validation.IsURLWithHTTPorHTTPS, description: "URL of the Vault server"},
+	{attribute: "token", secret: true, description: "Vault token used instead of the AppRole or TLS authentication"},
+	{attribute: "cacert", validate: validateCredentialCertificate, description: "PEM encoded certificate authority used to verify the Vault server"},
+	{attribute: "role_id", description: "Role ID of the AppRole authentication"},
+	{attribute: "secret_id", secret: true, requiredWith: []string{"role_id"}, description: "Secret ID of the AppRole authentication"},
+	{attribute: "client_cert_public", validate: validateCredentialCertificate, description: "PEM encoded client certificate of the TLS authentication"},
+	{attribute: "client_cert_private_key", secret: true, validate: validateCredentialPrivateKey, requiredWith: []string{"client_cert_public"}, description: "PEM encoded private key of the client certificate"},
+	{attribute: "client_cert_role", requiredWith: []string{"client_cert_public"}, description: "Role of the TLS authentication"},
+	{attribute: "namespace", description: "Vault namespace, Vault Enterprise only"},
+	{attribute: "default_auth_path", defaultValue: "approle", description: "Mount path of the AppRole or TLS authentication"},
+}
+
+var credentialKindHashiCorpVaultLookup = &credentialKind{
+	name:      "HashiCorp Vault Secret Lookup",
+	namespace: "hashivault_kv",
+	inputs: append([]credentialInput{
+		{
+			attribute:    "api_version",
+			defaultValue: "v1",
+			validate:     validation.StringInSlice([]string{"v1", "v2"}, false),
+			description:  "Version of the KV secrets engine, one of v1 or v2",
+		},
+	}, hashicorpVaultInputs...),
+	metadata: []credentialInput{
+		{attribute: "secret_path", required: true, description: "Path to the secret"},
+		{attribute: "secret_key", required: true, description: "Key of the value in the secret"},
+		{attribute: "secret_backend", description: "Mount path of the KV secrets engine, if not part of secret_path"},
+		{attribute: "auth_path", description: "Mount path of the authentication, overrides default_auth_path of the credential"},
+		{
+			attribute:   "secret_version",
+			validate:    validation.StringMatch(regexp.MustCompile(`^[0-9]+$`), "must be a version number"),
+			description: "Version of the secret, v2 KV secrets engine only",
+		},
+	},
+}
+
+func resourceCredentialHashiCorpVaultLookup() *schema.Resource {
+	return resourceCredentialKind(credentialKindHashiCorpVaultLookup)
+}
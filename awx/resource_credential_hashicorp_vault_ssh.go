@@ -0,0 +1,70 @@
+/*
+`awx_credential_hashicorp_vault_ssh` manages HashiCorp Vault Signed SSH credentials, used as source of `awx_credential_input_source` to sign SSH public keys with the SSH secrets engine.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_hashicorp_vault_ssh" "vault" {
+	  name            = "vault-ssh"
+	  organization_id = awx_organization.default.id
+	  url             = "https://vault.example.com:8200"
+	  token           = var.vault_token
+	}
+
+	resource "awx_credential_input_source" "certificate" {
+	  input_field_name = "ssh_public_key_data"
+	  target           = awx_credential_machine.deploy.id
+	  source           = awx_credential_hashicorp_vault_ssh.vault.id
+
+	  hashicorp_vault_ssh {
+	    secret_path      = "ssh-client-signer"
+	    role             = "deploy"
+	    public_key       = file("${path.module}/id_rsa.pub")
+	    valid_principals = "deploy"
+	  }
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_hashicorp_vault_ssh.vault 7
+terraform import awx_credential_hashicorp_vault_ssh.vault Default/my-credential
+```
+*/
+package awx
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var credentialKindHashiCorpVaultSSH = &credentialKind{
+	name:      "HashiCorp Vault Signed SSH",
+	namespace: "hashivault_ssh",
+	inputs:    hashicorpVaultInputs,
+	metadata: []credentialInput{
+		{attribute: "secret_path", required: true, description: "Mount path of the SSH secrets engine"},
+		{attribute: "role", required: true, description: "Role used to sign the public key"},
+		{
+			attribute:   "public_key",
+			required:    true,
+			validate:    validation.StringMatch(regexp.MustCompile(`^\s*(ssh|ecdsa)-[a-z0-9-]+ `), "must be an SSH public key"),
+			description: "SSH public key to sign",
+		},
+		{attribute: "valid_principals", description: "Comma separated principals the certificate is valid for"},
+		{attribute: "auth_path", description: "Mount path of the authentication, overrides default_auth_path of the credential"},
+	},
+}
+
+func resourceCredentialHashiCorpVaultSSH() *schema.Resource {
+	return resourceCredentialKind(credentialKindHashiCorpVaultSSH)
+}
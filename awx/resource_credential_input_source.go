@@ -1,10 +1,47 @@
 /*
-*TBD*
+`awx_credential_input_source` looks up an input of the target credential
+from the secret lookup plugin credential given as source, for example
+HashiCorp Vault or CyberArk.
+
+The metadata of the lookup is given in the block of the source credential
+type, which is validated at plan time, or as free-form `metadata` for source
+credential types without a block.
 
 # Example Usage
 
 ```hcl
-*TBD*
+
+	resource "awx_credential_input_source" "password" {
+	  input_field_name = "password"
+	  target           = awx_credential_machine.deploy.id
+	  source           = awx_credential_hashicorp_vault_lookup.vault.id
+
+	  hashicorp_vault_lookup {
+	    secret_path = "/kv/deploy"
+	    secret_key  = "password"
+	  }
+	}
+
+	resource "awx_credential_input_source" "secret" {
+	  input_field_name = "secret"
+	  target           = awx_credential_azure_key_vault.target.id
+	  source           = awx_credential_azure_key_vault.source.id
+	  metadata = {
+	    secret_field = "client-secret"
+	  }
+	}
+
+```
+
+# Import
+
+Credential input sources can be imported using the input source ID or the
+name path `<organization>/<credential>/<input_field_name>` of the target
+credential and its input.
+
+```sh
+terraform import awx_credential_input_source.example 3
+terraform import awx_credential_input_source.example Default/deploy/password
 ```
 */
 package awx
@@ -12,6 +49,7 @@ package awx
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -19,8 +57,36 @@ import (
 	awx "github.com/mrcrilly/goawx/client"
 )
 
+// credentialInputSourceTypes are the blocks of the typed lookup metadata,
+// one for every secret lookup plugin with a typed credential resource.
+var credentialInputSourceTypes = []string{
+	"cyberark_ccp",
+	"hashicorp_vault_lookup",
+	"hashicorp_vault_ssh",
+	"thycotic_dsv",
+	"thycotic_secret_server",
+}
+
+var credentialInputSourceKinds = map[string]*credentialKind{
+	"cyberark_ccp":           credentialKindCyberArkCCP,
+	"hashicorp_vault_lookup": credentialKindHashiCorpVaultLookup,
+	"hashicorp_vault_ssh":    credentialKindHashiCorpVaultSSH,
+	"thycotic_dsv":           credentialKindThycoticDSV,
+	"thycotic_secret_server": credentialKindThycoticSecretServer,
+}
+
+// awxCredentialInputSource is the AWX credential input source object.
+type awxCredentialInputSource struct {
+	ID               int                    `json:"id"`
+	Description      string                 `json:"description"`
+	InputFieldName   string                 `json:"input_field_name"`
+	TargetCredential int                    `json:"target_credential"`
+	SourceCredential int                    `json:"source_credential"`
+	Metadata         map[string]interface{} `json:"metadata"`
+}
+
 func resourceCredentialInputSource() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceCredentialInputSourceCreate,
 		ReadContext:   resourceCredentialInputSourceRead,
 		UpdateContext: resourceCredentialInputSourceUpdate,
@@ -47,22 +113,150 @@ func resourceCredentialInputSource() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Optional: true,
+				Optional:      true,
+				ConflictsWith: credentialInputSourceTypes,
+				Description:   "Free-form metadata of the lookup, validated like the block of the source credential type if it has one",
 			},
 		},
+		CustomizeDiff: customizeDiffCredentialInputSource,
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathCredentialInputSource),
+		},
+	}
+	for _, t := range credentialInputSourceTypes {
+		k := credentialInputSourceKinds[t]
+		fields := make(map[string]*schema.Schema, len(k.metadata))
+		for _, input := range k.metadata {
+			fields[input.attribute] = input.schema()
+		}
+		r.Schema[t] = &schema.Schema{
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: credentialInputSourceConflicts(t),
+			Elem:          &schema.Resource{Schema: fields},
+			Description:   fmt.Sprintf("Metadata of the lookup from a %s source credential", k.name),
+		}
+	}
+	return r
+}
+
+// credentialInputSourceConflicts returns the metadata attributes which
+// conflict with the metadata block t.
+func credentialInputSourceConflicts(t string) []string {
+	conflicts := []string{"metadata"}
+	for _, other := range credentialInputSourceTypes {
+		if other != t {
+			conflicts = append(conflicts, other)
+		}
+	}
+	return conflicts
+}
+
+// credentialInputSourceMetadata returns the metadata block set in the
+// configuration and the metadata to send to AWX.
+func credentialInputSourceMetadata(get func(string) interface{}) (string, map[string]interface{}) {
+	for _, t := range credentialInputSourceTypes {
+		blocks := get(t).([]interface{})
+		if len(blocks) == 0 || blocks[0] == nil {
+			continue
+		}
+		block := blocks[0].(map[string]interface{})
+		metadata := make(map[string]interface{})
+		for _, input := range credentialInputSourceKinds[t].metadata {
+			if value := block[input.attribute].(string); value != "" {
+				metadata[input.inputID()] = value
+			}
+		}
+		return t, metadata
+	}
+	return "", get("metadata").(map[string]interface{})
+}
+
+// credentialInputSourceType returns the metadata block of the credential
+// type namespace, empty if it has none.
+func credentialInputSourceType(namespace string) string {
+	for _, t := range credentialInputSourceTypes {
+		if credentialInputSourceKinds[t].namespace == namespace {
+			return t
+		}
+	}
+	return ""
+}
+
+// customizeDiffCredentialInputSource validates the metadata against the
+// source credential type once the source is known. Secret lookup plugins
+// with a block still accept free-form metadata, which is validated the same
+// way as the block.
+func customizeDiffCredentialInputSource(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	changed := false
+	for _, key := range append([]string{"source", "metadata"}, credentialInputSourceTypes...) {
+		changed = changed || d.HasChange(key)
+	}
+	if !changed || !d.NewValueKnown("source") || !d.NewValueKnown("metadata") {
+		return nil
+	}
+	source := d.Get("source").(int)
+	namespace, err := credentialNamespace(ctx, m, source)
+	if err != nil {
+		return fmt.Errorf("unable to read the source credential %d, %s", source, err)
+	}
+
+	want := credentialInputSourceType(namespace)
+	got, metadata := credentialInputSourceMetadata(d.Get)
+	switch {
+	case got != "" && got != want:
+		return fmt.Errorf("the source credential %d is not a %s credential, %s cannot be used", source, credentialInputSourceKinds[got].name, got)
+	case got == "" && want != "":
+		return validateCredentialMetadata(credentialInputSourceKinds[want], metadata)
+	}
+	return nil
+}
+
+// validateCredentialMetadata validates free-form metadata against the
+// metadata of a secret lookup plugin.
+func validateCredentialMetadata(k *credentialKind, metadata map[string]interface{}) error {
+	known := make(map[string]bool, len(k.metadata))
+	for _, input := range k.metadata {
+		known[input.inputID()] = true
+		value, _ := metadata[input.inputID()].(string)
+		if value == "" {
+			if input.required && input.defaultValue == nil {
+				return fmt.Errorf("metadata.%s is required by %s source credentials", input.inputID(), k.name)
+			}
+			continue
+		}
+		if input.validate == nil {
+			continue
+		}
+		if _, errs := input.validate(value, "metadata."+input.inputID()); len(errs) > 0 {
+			return errs[0]
+		}
+	}
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !known[key] {
+			return fmt.Errorf("metadata.%s is not supported by %s source credentials", key, k.name)
+		}
+	}
+	return nil
 }
 
 func resourceCredentialInputSourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	var err error
 
+	_, metadata := credentialInputSourceMetadata(d.Get)
 	newSourceInput := map[string]interface{}{
 		"description":       d.Get("description").(string),
 		"input_field_name":  d.Get("input_field_name").(string),
 		"target_credential": d.Get("target").(int),
 		"source_credential": d.Get("source").(int),
-		"metadata":          d.Get("metadata").(map[string]interface{}),
+		"metadata":          metadata,
 	}
 
 	client := m.(*awx.AWX)
@@ -83,26 +277,53 @@ func resourceCredentialInputSourceCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCredentialInputSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+	id, diags := convertStateIDToNummeric("Read CredentialInputSource", d)
+	if diags.HasError() {
+		return diags
+	}
 
-	client := m.(*awx.AWX)
-	id, _ := strconv.Atoi(d.Id())
-	inputSource, err := client.CredentialInputSourceService.GetCredentialInputSourceByID(id, map[string]string{})
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to fetch credentials",
-			Detail:   fmt.Sprintf("Unable to credentials with id %d: %s", id, err.Error()),
-		})
+	var inputSource awxCredentialInputSource
+	err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/credential_input_sources/%d/", id), nil, &inputSource)
+	if isNotFound(err) {
+		d.SetId("")
 		return diags
 	}
+	if err != nil {
+		return buildDiagNotFoundFail("credential input source", id, err)
+	}
 
 	d.Set("description", inputSource.Description)
 	d.Set("input_field_name", inputSource.InputFieldName)
 	d.Set("target", inputSource.TargetCredential)
 	d.Set("source", inputSource.SourceCredential)
-	d.Set("metadata", inputSource.Metadata)
 
+	// Free-form metadata stays free-form, otherwise the metadata is read
+	// into the block of the source credential type.
+	t := ""
+	if len(d.Get("metadata").(map[string]interface{})) == 0 {
+		namespace, err := credentialNamespace(ctx, m, inputSource.SourceCredential)
+		if err != nil {
+			return buildDiagNotFoundFail("source credential", inputSource.SourceCredential, err)
+		}
+		t = credentialInputSourceType(namespace)
+	}
+	for _, other := range credentialInputSourceTypes {
+		if other != t {
+			d.Set(other, nil)
+		}
+	}
+	if t == "" {
+		d.Set("metadata", inputSource.Metadata)
+		return diags
+	}
+
+	block := make(map[string]interface{})
+	for _, input := range credentialInputSourceKinds[t].metadata {
+		value, _ := inputSource.Metadata[input.inputID()].(string)
+		block[input.attribute] = value
+	}
+	d.Set("metadata", nil)
+	d.Set(t, []interface{}{block})
 	return diags
 }
 
@@ -116,17 +337,19 @@ func resourceCredentialInputSourceUpdate(ctx context.Context, d *schema.Resource
 		"source",
 		"metadata",
 	}
+	keys = append(keys, credentialInputSourceTypes...)
 
 	if d.HasChanges(keys...) {
 		var err error
 
 		id, _ := strconv.Atoi(d.Id())
+		_, metadata := credentialInputSourceMetadata(d.Get)
 		updatedSourceInput := map[string]interface{}{
 			"description":       d.Get("description").(string),
 			"input_field_name":  d.Get("input_field_name").(string),
 			"target_credential": d.Get("target").(int),
 			"source_credential": d.Get("source").(int),
-			"metadata":          d.Get("metadata").(map[string]interface{}),
+			"metadata":          metadata,
 		}
 
 		client := m.(*awx.AWX)
@@ -0,0 +1,140 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testCredentialInputSourceConfig(source, lookup string) string {
+	return fmt.Sprintf(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_credential_machine" "target" {
+  name            = "test-target"
+  organization_id = awx_organization.test.id
+  username        = "deploy"
+}
+
+resource "awx_credential_hashicorp_vault_lookup" "vault" {
+  name            = "test-vault"
+  organization_id = awx_organization.test.id
+  url             = "https://vault.example.com:8200"
+  api_version     = "v2"
+  role_id         = "role"
+  secret_id       = "secret"
+}
+
+resource "awx_credential_cyberark_ccp" "cyberark" {
+  name            = "test-cyberark"
+  organization_id = awx_organization.test.id
+  url             = "https://ccp.example.com"
+  app_id          = "awx"
+}
+
+resource "awx_credential_input_source" "test" {
+  input_field_name = "password"
+  target           = awx_credential_machine.target.id
+  source           = %s.id
+%s
+}
+`, source, lookup)
+}
+
+func TestResourceCredentialInputSource(t *testing.T) {
+	fake := newFakeAWX(t)
+	vault := "awx_credential_hashicorp_vault_lookup.vault"
+	cyberark := "awx_credential_cyberark_ccp.cyberark"
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_credential_input_source", "credential_input_sources"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testCredentialInputSourceConfig(vault, `
+  hashicorp_vault_lookup {
+    secret_path = "/kv/deploy"
+    secret_key  = "password"
+  }
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_credential_input_source.test", "hashicorp_vault_lookup.0.secret_path", "/kv/deploy"),
+					fake.checkCredentialType(vault, "hashivault_kv"),
+					fake.checkField("awx_credential_input_source.test", "credential_input_sources", "metadata", map[string]interface{}{
+						"secret_path": "/kv/deploy",
+						"secret_key":  "password",
+					}),
+				),
+			},
+			{
+				Config: fake.config(testCredentialInputSourceConfig(vault, `
+  hashicorp_vault_lookup {
+    secret_path    = "/kv/deploy"
+    secret_key     = "password"
+    secret_version = "3"
+  }
+`)),
+				Check: fake.checkField("awx_credential_input_source.test", "credential_input_sources", "metadata", map[string]interface{}{
+					"secret_path":    "/kv/deploy",
+					"secret_key":     "password",
+					"secret_version": "3",
+				}),
+			},
+			{
+				Config: fake.config(testCredentialInputSourceConfig(vault, `
+  hashicorp_vault_lookup {
+    secret_path    = "/kv/deploy"
+    secret_key     = "password"
+    secret_version = "latest"
+  }
+`)),
+				ExpectError: regexp.MustCompile("must be a version number"),
+			},
+			{
+				Config: fake.config(testCredentialInputSourceConfig(cyberark, `
+  hashicorp_vault_lookup {
+    secret_path = "/kv/deploy"
+    secret_key  = "password"
+  }
+`)),
+				ExpectError: regexp.MustCompile("is not a HashiCorp Vault Secret Lookup credential"),
+			},
+			{
+				Config: fake.config(testCredentialInputSourceConfig(cyberark, `
+  metadata = {
+    reason = "deploy"
+  }
+`)),
+				ExpectError: regexp.MustCompile("metadata.object_query is required"),
+			},
+			{
+				ResourceName:      "awx_credential_input_source.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_credential_input_source.test",
+				ImportState:       true,
+				ImportStateId:     "test-org/test-target/password",
+				ImportStateVerify: true,
+			},
+			{
+				// Free-form metadata is still accepted once validated.
+				Config: fake.config(testCredentialInputSourceConfig(cyberark, `
+  metadata = {
+    object_query = "Safe=Deploy;Object=deploy"
+  }
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_credential_input_source.test", "cyberark_ccp.#", "0"),
+					fake.checkField("awx_credential_input_source.test", "credential_input_sources", "metadata", map[string]interface{}{
+						"object_query": "Safe=Deploy;Object=deploy",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,45 @@
+/*
+`awx_credential_insights` manages Insights credentials, used by Insights inventory sources and projects.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_insights" "insights" {
+	  name            = "insights"
+	  organization_id = awx_organization.default.id
+	  username        = "insights-user"
+	  password        = var.insights_password
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_insights.insights 7
+terraform import awx_credential_insights.insights Default/my-credential
+```
+*/
+package awx
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var credentialKindInsights = &credentialKind{
+	name:      "Insights",
+	namespace: "insights",
+	inputs: []credentialInput{
+		{attribute: "username", required: true, description: "Username of the Red Hat account"},
+		{attribute: "password", required: true, secret: true, description: "Password of the Red Hat account"},
+	},
+}
+
+func resourceCredentialInsights() *schema.Resource {
+	return resourceCredentialKind(credentialKindInsights)
+}
@@ -0,0 +1,50 @@
+/*
+`awx_credential_kubernetes_bearer_token` manages OpenShift or Kubernetes API Bearer Token credentials, used by container groups to run jobs on a cluster.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_kubernetes_bearer_token" "cluster" {
+	  name            = "cluster"
+	  organization_id = awx_organization.default.id
+	  host            = "https://api.cluster.example.com:6443"
+	  bearer_token    = var.service_account_token
+	  verify_ssl      = true
+	  ssl_ca_cert     = file("${path.module}/ca.crt")
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_kubernetes_bearer_token.cluster 7
+terraform import awx_credential_kubernetes_bearer_token.cluster Default/my-credential
+```
+*/
+package awx
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var credentialKindKubernetesBearerToken = &credentialKind{
+	name:      "OpenShift or Kubernetes API Bearer Token",
+	namespace: "kubernetes_bearer_token",
+	inputs: []credentialInput{
+		{attribute: "host", required: true, validate: validation.IsURLWithHTTPorHTTPS, description: "URL of the OpenShift or Kubernetes API"},
+		{attribute: "bearer_token", required: true, secret: true, description: "Bearer token of the service account"},
+		{attribute: "verify_ssl", boolean: true, description: "Verify the TLS certificate of the API"},
+		{attribute: "ssl_ca_cert", secret: true, validate: validateCredentialCertificate, description: "PEM encoded certificate authority used to verify the API"},
+	},
+}
+
+func resourceCredentialKubernetesBearerToken() *schema.Resource {
+	return resourceCredentialKind(credentialKindKubernetesBearerToken)
+}
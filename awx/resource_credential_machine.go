@@ -6,6 +6,17 @@
 ```hcl
 *TBD*
 ```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_machine.example 7
+terraform import awx_credential_machine.example Default/my-credential
+```
 */
 package awx
 
@@ -75,6 +86,9 @@ func resourceCredentialMachine() *schema.Resource {
 				Sensitive: true,
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathCredential),
+		},
 	}
 }
 
@@ -0,0 +1,60 @@
+/*
+`awx_credential_network` manages Network credentials, used by the network modules to connect to devices.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_network" "switches" {
+	  name               = "switches"
+	  organization_id    = awx_organization.default.id
+	  username           = "admin"
+	  ssh_key_data       = file("${path.module}/id_rsa")
+	  authorize          = true
+	  authorize_password = var.enable_password
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_network.switches 7
+terraform import awx_credential_network.switches Default/my-credential
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var credentialKindNetwork = &credentialKind{
+	name:      "Network",
+	namespace: "net",
+	inputs: []credentialInput{
+		{attribute: "username", required: true, description: "Username used to log in to the device"},
+		{attribute: "password", secret: true, description: "Password used to log in to the device"},
+		{attribute: "ssh_key_data", secret: true, validate: validateCredentialPrivateKey, description: "PEM encoded SSH private key"},
+		{attribute: "ssh_key_unlock", secret: true, requiredWith: []string{"ssh_key_data"}, description: "Passphrase of the SSH private key"},
+		{attribute: "authorize", boolean: true, description: "Enter privileged mode on the device"},
+		{attribute: "authorize_password", secret: true, description: "Password used to enter privileged mode, requires authorize"},
+	},
+	customizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Get("authorize_password").(string) != "" && !d.Get("authorize").(bool) {
+			return fmt.Errorf("authorize_password requires authorize to be enabled")
+		}
+		return nil
+	},
+}
+
+func resourceCredentialNetwork() *schema.Resource {
+	return resourceCredentialKind(credentialKindNetwork)
+}
@@ -6,6 +6,17 @@
 ```hcl
 *TBD*
 ```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_scm.example 7
+terraform import awx_credential_scm.example Default/my-credential
+```
 */
 package awx
 
@@ -58,6 +69,9 @@ func resourceCredentialSCM() *schema.Resource {
 				Sensitive: true,
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathCredential),
+		},
 	}
 }
 
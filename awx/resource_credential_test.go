@@ -0,0 +1,151 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testCredentialConfig(username string) string {
+	return fmt.Sprintf(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_credential" "test" {
+  name               = "test-credential"
+  organization_id    = awx_organization.test.id
+  credential_type_id = 1
+  inputs = jsonencode({
+    username = %q
+    password = "secret"
+  })
+}
+`, username)
+}
+
+func TestResourceCredential(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_credential", "credentials"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testCredentialConfig("alice")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_credential.test", "name", "test-credential"),
+					fake.checkField("awx_credential.test", "credentials", "inputs", map[string]interface{}{"username": "alice", "password": "secret"}),
+				),
+			},
+			{
+				Config: fake.config(testCredentialConfig("bob")),
+				Check:  fake.checkField("awx_credential.test", "credentials", "inputs", map[string]interface{}{"username": "bob", "password": "secret"}),
+			},
+			{
+				ResourceName:            "awx_credential.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"inputs"},
+			},
+		},
+	})
+}
+
+func testCredentialInputsConfig(inputs string) string {
+	return fmt.Sprintf(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_credential_type" "api" {
+  name = "api"
+  inputs = jsonencode({
+    fields = [
+      { id = "token", label = "Token", type = "string", secret = true },
+      { id = "region", label = "Region", type = "string", choices = ["eu", "us"] },
+      { id = "verbose", label = "Verbose", type = "boolean" },
+    ]
+    required = ["token"]
+  })
+  injectors = jsonencode({
+    env = { API_TOKEN = "{{ token }}" }
+  })
+}
+
+resource "awx_credential" "api" {
+  name               = "api"
+  organization_id    = awx_organization.test.id
+  credential_type_id = awx_credential_type.api.id
+  inputs             = jsonencode(%s)
+}
+`, inputs)
+}
+
+func TestResourceCredentialInputsValidation(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_credential", "credentials"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testCredentialInputsConfig(`{ token = "secret", region = "eu" }`)),
+				Check:  fake.checkField("awx_credential.api", "credentials", "inputs", map[string]interface{}{"token": "secret", "region": "eu"}),
+			},
+			{
+				Config:      fake.config(testCredentialInputsConfig(`{ token = "secret", region = "ap" }`)),
+				ExpectError: regexp.MustCompile(`region must be one of eu, us, got "ap"`),
+			},
+			{
+				Config:      fake.config(testCredentialInputsConfig(`{ region = "eu" }`)),
+				ExpectError: regexp.MustCompile("token is required"),
+			},
+			{
+				Config:      fake.config(testCredentialInputsConfig(`{ token = "secret", verbose = "yes" }`)),
+				ExpectError: regexp.MustCompile("verbose must be a boolean"),
+			},
+			{
+				Config:      fake.config(testCredentialInputsConfig(`{ token = "secret", colour = "blue" }`)),
+				ExpectError: regexp.MustCompile("colour is not an input of the credential type"),
+			},
+			{
+				Config: fake.config(testCredentialInputsConfig(`{ token = "rotated", verbose = true }`)),
+				Check:  fake.checkField("awx_credential.api", "credentials", "inputs", map[string]interface{}{"token": "rotated", "verbose": true}),
+			},
+		},
+	})
+}
+
+func TestValidateCredentialInputs(t *testing.T) {
+	definition := awxCredentialTypeInputs{
+		Fields: []awxCredentialTypeField{
+			{ID: "username", Type: "string"},
+			{ID: "password", Type: "string", Secret: true, Choices: []interface{}{"ASK"}},
+			{ID: "ssh_key_data", Type: "string", Secret: true, Format: "ssh_private_key"},
+		},
+		Required: []string{"username"},
+	}
+	if err := validateCredentialInputs("Machine", definition, map[string]interface{}{"username": "alice"}); err != nil {
+		t.Errorf("valid inputs, got %s", err)
+	}
+
+	err := validateCredentialInputs("Machine", definition, map[string]interface{}{
+		"password":     "hunter2",
+		"ssh_key_data": "not-a-key",
+	})
+	if err == nil {
+		t.Fatal("invalid inputs, got no error")
+	}
+	for _, want := range []string{"password must be one of ASK, got the value", "ssh_key_data must be a PEM encoded private key", "username is required"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+	for _, secret := range []string{"hunter2", "not-a-key"} {
+		if strings.Contains(err.Error(), secret) {
+			t.Errorf("error %q shows the secret %q", err, secret)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+/*
+`awx_credential_thycotic_dsv` manages Thycotic DevOps Secrets Vault credentials, used as source of `awx_credential_input_source` to read secrets from DevOps Secrets Vault.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_thycotic_dsv" "dsv" {
+	  name            = "dsv"
+	  organization_id = awx_organization.default.id
+	  tenant          = "example"
+	  client_id       = var.dsv_client_id
+	  client_secret   = var.dsv_client_secret
+	}
+
+	resource "awx_credential_input_source" "password" {
+	  input_field_name = "password"
+	  target           = awx_credential_machine.deploy.id
+	  source           = awx_credential_thycotic_dsv.dsv.id
+
+	  thycotic_dsv {
+	    path         = "/deploy/ssh"
+	    secret_field = "password"
+	  }
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_thycotic_dsv.dsv 7
+terraform import awx_credential_thycotic_dsv.dsv Default/my-credential
+```
+*/
+package awx
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var credentialKindThycoticDSV = &credentialKind{
+	name:      "Thycotic DevOps Secrets Vault",
+	namespace: "thycotic_dsv",
+	inputs: []credentialInput{
+		{attribute: "tenant", required: true, description: "Tenant name, the first part of the tenant URL"},
+		{
+			attribute:    "tld",
+			defaultValue: "com",
+			validate:     validation.StringInSlice([]string{"ca", "com", "com.au", "eu"}, false),
+			description:  "Top level domain of the tenant URL, one of ca, com, com.au or eu",
+		},
+		{attribute: "client_id", required: true, description: "Client ID"},
+		{attribute: "client_secret", required: true, secret: true, description: "Client secret"},
+	},
+	metadata: []credentialInput{
+		{attribute: "path", required: true, description: "Path to the secret"},
+		{attribute: "secret_field", required: true, description: "Field of the secret data to return"},
+	},
+}
+
+func resourceCredentialThycoticDSV() *schema.Resource {
+	return resourceCredentialKind(credentialKindThycoticDSV)
+}
@@ -0,0 +1,71 @@
+/*
+`awx_credential_thycotic_secret_server` manages Thycotic Secret Server credentials, used as source of `awx_credential_input_source` to read secrets from Secret Server.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_thycotic_secret_server" "tss" {
+	  name            = "secret-server"
+	  organization_id = awx_organization.default.id
+	  server_url      = "https://example.secretservercloud.com/SecretServer"
+	  username        = "awx"
+	  password        = var.tss_password
+	}
+
+	resource "awx_credential_input_source" "password" {
+	  input_field_name = "password"
+	  target           = awx_credential_machine.deploy.id
+	  source           = awx_credential_thycotic_secret_server.tss.id
+
+	  thycotic_secret_server {
+	    secret_id    = "42"
+	    secret_field = "password"
+	  }
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_thycotic_secret_server.tss 7
+terraform import awx_credential_thycotic_secret_server.tss Default/my-credential
+```
+*/
+package awx
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var credentialKindThycoticSecretServer = &credentialKind{
+	name:      "Thycotic Secret Server",
+	namespace: "thycotic_tss",
+	inputs: []credentialInput{
+		{attribute: "server_url", required: true, validate: validation.IsURLWithHTTPorHTTPS, description: "URL of the Secret Server"},
+		{attribute: "username", required: true, description: "Username of the application user"},
+		{attribute: "password", required: true, secret: true, description: "Password of the application user"},
+		{attribute: "domain", description: "Domain of the application user"},
+	},
+	metadata: []credentialInput{
+		{
+			attribute:   "secret_id",
+			required:    true,
+			validate:    validation.StringMatch(regexp.MustCompile(`^[0-9]+$`), "must be a numeric secret ID"),
+			description: "ID of the secret",
+		},
+		{attribute: "secret_field", required: true, description: "Slug of the secret field to return"},
+	},
+}
+
+func resourceCredentialThycoticSecretServer() *schema.Resource {
+	return resourceCredentialKind(credentialKindThycoticSecretServer)
+}
@@ -1,10 +1,54 @@
 /*
-*TBD*
+The `awx_credential_type` resource manages a custom credential type.
+
+The inputs of the credential type are described with `field` blocks and the
+`required` list, its injectors with the `env`, `extra_vars` and `file` maps.
+Every injector template must only reference defined fields, or the files of
+the `file` injector through `tower.filename`. The raw `inputs` and `injectors`
+JSON documents are still accepted instead of the typed blocks, the typed
+attributes are then read back from AWX.
 
 # Example Usage
 
 ```hcl
-*TBD*
+
+	resource "awx_credential_type" "api" {
+	  name = "api"
+
+	  field {
+	    id     = "token"
+	    label  = "Token"
+	    secret = true
+	  }
+
+	  field {
+	    id      = "region"
+	    label   = "Region"
+	    choices = ["eu", "us"]
+	  }
+
+	  required = ["token"]
+
+	  env = {
+	    API_TOKEN  = "{{ token }}"
+	    API_CONFIG = "{{ tower.filename.config }}"
+	  }
+
+	  file = {
+	    "template.config" = "region: {{ region }}"
+	  }
+	}
+
+```
+
+# Import
+
+Credential types can be imported using the credential type ID or the name path
+`<name>`.
+
+```sh
+terraform import awx_credential_type.example 30
+terraform import awx_credential_type.example my-credential-type
 ```
 */
 package awx
@@ -13,19 +57,32 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	awx "github.com/mrcrilly/goawx/client"
 )
 
+// credentialTypeInjectors are the injector maps of a credential type, each
+// mapping names to templates.
+var credentialTypeInjectors = []string{"env", "extra_vars", "file"}
+
+// credentialTypeReference matches the variable starting each template
+// expression along with its attributes, e.g. tower.filename.config.
+var credentialTypeReference = regexp.MustCompile(`{{-?\s*([A-Za-z_][A-Za-z0-9_]*)((?:\.[A-Za-z_][A-Za-z0-9_]*)*)`)
+
 func resourceCredentialType() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceCredentialTypeCreate,
 		ReadContext:   resourceCredentialTypeRead,
 		UpdateContext: resourceCredentialTypeUpdate,
 		DeleteContext: CredentialTypeServiceDeleteByID,
+		CustomizeDiff: customizeDiffCredentialType,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -43,54 +100,393 @@ func resourceCredentialType() *schema.Resource {
 				Default:     "cloud",
 				Description: "Choices cloud or net",
 			},
+			"field": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"inputs"},
+				Description:   "An input field of the credential type.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`), "must be a valid variable name"),
+							Description:  "ID of the field, referenced by the injector templates.",
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Label of the field.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "string",
+							ValidateFunc: validation.StringInSlice([]string{"string", "boolean"}, false),
+							Description:  "Type of the field, string or boolean.",
+						},
+						"secret": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the value of the field is encrypted.",
+						},
+						"multiline": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the field is a multiline text field.",
+						},
+						"choices": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Allowed values of a string field.",
+						},
+						"help_text": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Help text of the field.",
+						},
+					},
+				},
+			},
+			"required": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"inputs"},
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Description:   "IDs of the fields that must be set.",
+			},
+			"env": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"injectors"},
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Description:   "Environment variables injected into the job, mapped to their templates.",
+			},
+			"extra_vars": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"injectors"},
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Description:   "Extra variables injected into the job, mapped to their templates.",
+			},
+			"file": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"injectors"},
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Description:   "Files written for the job, `template` or `template.<name>` mapped to their templates.",
+			},
 			"inputs": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"field", "required"},
+				ValidateFunc:  validation.StringIsJSON,
+				StateFunc:     normalizeJsonYaml,
+				Description:   "Inputs of the credential type as a JSON document, instead of the field blocks.",
 			},
 			"injectors": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: credentialTypeInjectors,
+				ValidateFunc:  validation.StringIsJSON,
+				StateFunc:     normalizeJsonYaml,
+				Description:   "Injectors of the credential type as a JSON document, instead of the injector maps.",
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathCredentialType),
+		},
 	}
 }
 
-func resourceCredentialTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+// customizeDiffCredentialType keeps the raw documents and the typed
+// attributes in sync, and validates the fields referenced by the required
+// list and the injector templates. The typed attributes are only computed
+// when the raw document is configured, otherwise removing them from the
+// configuration empties them.
+func customizeDiffCredentialType(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if config := d.GetRawConfig(); !config.IsNull() && config.IsKnown() {
+		if config.GetAttr("inputs").IsNull() {
+			if err := clearUnconfigured(d, []interface{}{}, "field", "required"); err != nil {
+				return err
+			}
+		}
+		if config.GetAttr("injectors").IsNull() {
+			if err := clearUnconfigured(d, map[string]interface{}{}, credentialTypeInjectors...); err != nil {
+				return err
+			}
+		}
+	}
+
+	typed := append([]string{"field", "required"}, credentialTypeInjectors...)
+	changed := d.HasChange("inputs") || d.HasChange("injectors")
+	for _, key := range typed {
+		changed = changed || d.HasChange(key)
+	}
+	if !changed {
+		return nil
+	}
+
+	// Whichever representation changed is planned, the other one is read
+	// back from AWX.
 	var err error
+	if d.HasChange("inputs") {
+		err = setNewComputed(d, "field", "required")
+	} else if d.HasChange("field") || d.HasChange("required") {
+		err = setNewComputed(d, "inputs")
+	}
+	if err != nil {
+		return err
+	}
+	if d.HasChange("injectors") {
+		err = setNewComputed(d, credentialTypeInjectors...)
+	} else if d.HasChange("env") || d.HasChange("extra_vars") || d.HasChange("file") {
+		err = setNewComputed(d, "injectors")
+	}
+	if err != nil {
+		return err
+	}
+	if !credentialTypeKnown(d, "inputs", "field", "required") || !credentialTypeKnown(d, "injectors", credentialTypeInjectors...) {
+		return nil
+	}
 
-	inputs := d.Get("inputs").(string)
-	inputs_map := make(map[string]interface{})
-	inputs_jsonerr := json.Unmarshal([]byte(inputs), &inputs_map)
+	inputs, err := credentialTypeInputs(d.Get)
+	if err != nil {
+		return err
+	}
+	injectors, err := credentialTypeInjectorsPayload(d.Get)
+	if err != nil {
+		return err
+	}
+	return validateCredentialType(inputs, injectors)
+}
 
-	if inputs_jsonerr != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new Credential Type",
-			Detail:   fmt.Sprintf("Unable to create new credential type: %s", inputs_jsonerr.Error()),
-		})
-		return diags
+// clearUnconfigured plans the optional and computed keys missing from the
+// configuration or configured empty as empty.
+func clearUnconfigured(d *schema.ResourceDiff, empty interface{}, keys ...string) error {
+	config := d.GetRawConfig()
+	for _, key := range keys {
+		if v := config.GetAttr(key); !v.IsNull() && (!v.IsKnown() || v.LengthInt() > 0) {
+			continue
+		}
+		switch v := d.Get(key).(type) {
+		case []interface{}:
+			if len(v) == 0 {
+				continue
+			}
+		case map[string]interface{}:
+			if len(v) == 0 {
+				continue
+			}
+		}
+		if err := d.SetNew(key, empty); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	injectors := d.Get("injectors").(string)
-	injectors_map := make(map[string]interface{})
-	injectors_jsonerr := json.Unmarshal([]byte(injectors), &injectors_map)
+func setNewComputed(d *schema.ResourceDiff, keys ...string) error {
+	for _, key := range keys {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	if injectors_jsonerr != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new Credential Type",
-			Detail:   fmt.Sprintf("Unable to create new credential type: %s", injectors_jsonerr.Error()),
-		})
-		return diags
+// credentialTypeKnown reports whether either the raw document or all of the
+// typed attributes are known.
+func credentialTypeKnown(d *schema.ResourceDiff, raw string, typed ...string) bool {
+	if d.NewValueKnown(raw) && d.Get(raw).(string) != "" {
+		return true
+	}
+	for _, key := range typed {
+		if !d.NewValueKnown(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// credentialTypeInputs returns the inputs from the raw document when it is
+// set, from the field blocks otherwise.
+func credentialTypeInputs(get func(string) interface{}) (map[string]interface{}, error) {
+	inputs := make(map[string]interface{})
+	if raw := get("inputs").(string); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &inputs); err != nil {
+			return nil, fmt.Errorf("inputs must be a JSON object, %s", err)
+		}
+		return inputs, nil
+	}
+
+	var fields []interface{}
+	for _, f := range get("field").([]interface{}) {
+		block := f.(map[string]interface{})
+		field := map[string]interface{}{
+			"id":    block["id"],
+			"label": block["label"],
+			"type":  block["type"],
+		}
+		if block["secret"].(bool) {
+			field["secret"] = true
+		}
+		if block["multiline"].(bool) {
+			field["multiline"] = true
+		}
+		if choices := block["choices"].([]interface{}); len(choices) > 0 {
+			field["choices"] = choices
+		}
+		if helpText := block["help_text"].(string); helpText != "" {
+			field["help_text"] = helpText
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) > 0 {
+		inputs["fields"] = fields
+	}
+	if required := get("required").([]interface{}); len(required) > 0 {
+		inputs["required"] = required
+	}
+	return inputs, nil
+}
+
+// credentialTypeInjectorsPayload returns the injectors from the raw document
+// when it is set, from the injector maps otherwise.
+func credentialTypeInjectorsPayload(get func(string) interface{}) (map[string]interface{}, error) {
+	injectors := make(map[string]interface{})
+	if raw := get("injectors").(string); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &injectors); err != nil {
+			return nil, fmt.Errorf("injectors must be a JSON object, %s", err)
+		}
+		return injectors, nil
+	}
+
+	for _, key := range credentialTypeInjectors {
+		if templates := get(key).(map[string]interface{}); len(templates) > 0 {
+			injectors[key] = templates
+		}
+	}
+	return injectors, nil
+}
+
+// validateCredentialType checks that the required list and the injector
+// templates only reference defined fields.
+func validateCredentialType(inputs, injectors map[string]interface{}) error {
+	var definition awxCredentialTypeInputs
+	b, _ := json.Marshal(inputs)
+	if err := json.Unmarshal(b, &definition); err != nil {
+		return fmt.Errorf("invalid inputs, %s", err)
+	}
+
+	var problems []string
+	fields := make(map[string]bool, len(definition.Fields))
+	for _, field := range definition.Fields {
+		if fields[field.ID] {
+			problems = append(problems, fmt.Sprintf("field %s is defined more than once", field.ID))
+		}
+		fields[field.ID] = true
+	}
+	for _, id := range definition.Required {
+		if !fields[id] {
+			problems = append(problems, fmt.Sprintf("required field %s is not defined", id))
+		}
+	}
+
+	files, _ := injectors["file"].(map[string]interface{})
+	for _, kind := range credentialTypeInjectors {
+		templates, ok := injectors[kind].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for name, template := range templates {
+			for _, ref := range credentialTypeReference.FindAllStringSubmatch(fmt.Sprint(template), -1) {
+				if problem := credentialTypeReferenceProblem(ref[1], ref[2], fields, files); problem != "" {
+					problems = append(problems, fmt.Sprintf("%s.%s %s", kind, name, problem))
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid credential type: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// credentialTypeReferenceProblem describes why a template reference is not
+// valid, empty if it is. tower and awx give access to the paths of the files
+// written by the file injector.
+func credentialTypeReferenceProblem(name, attributes string, fields map[string]bool, files map[string]interface{}) string {
+	if name != "tower" && name != "awx" {
+		if !fields[name] {
+			return fmt.Sprintf("references undefined field %s", name)
+		}
+		return ""
+	}
+	file := strings.TrimPrefix(attributes, ".filename")
+	if file == attributes {
+		return fmt.Sprintf("references unknown variable %s%s", name, attributes)
+	}
+	template := "template" + file
+	if _, ok := files[template]; !ok {
+		return fmt.Sprintf("references undefined file %s", template)
 	}
+	return ""
+}
 
-	newCredentialType := map[string]interface{}{
+// flattenCredentialTypeTemplate returns an injector template as a string,
+// templates which are not strings are encoded as JSON.
+func flattenCredentialTypeTemplate(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+func resourceCredentialTypePayload(d *schema.ResourceData) (map[string]interface{}, error) {
+	inputs, err := credentialTypeInputs(d.Get)
+	if err != nil {
+		return nil, err
+	}
+	injectors, err := credentialTypeInjectorsPayload(d.Get)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
 		"kind":        d.Get("kind").(string),
-		"inputs":      inputs_map,
-		"injectors":   injectors_map,
+		"inputs":      inputs,
+		"injectors":   injectors,
+	}, nil
+}
+
+func resourceCredentialTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	newCredentialType, err := resourceCredentialTypePayload(d)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Unable to create new Credential Type",
+			"Unable to create new credential type: %s", err.Error(),
+		)
 	}
 
 	client := m.(*awx.AWX)
@@ -105,17 +501,27 @@ func resourceCredentialTypeCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	d.SetId(strconv.Itoa(credtype.ID))
-	resourceCredentialTypeRead(ctx, d, m)
-
-	return diags
+	return resourceCredentialTypeRead(ctx, d, m)
 }
 
 func resourceCredentialTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+	id, diags := convertStateIDToNummeric("Read Credential Type", d)
+	if diags.HasError() {
+		return diags
+	}
 
-	client := m.(*awx.AWX)
-	id, _ := strconv.Atoi(d.Id())
-	credtype, err := client.CredentialTypeService.GetCredentialTypeByID(id, map[string]string{})
+	var credtype struct {
+		Name        string                 `json:"name"`
+		Description string                 `json:"description"`
+		Kind        string                 `json:"kind"`
+		Inputs      map[string]interface{} `json:"inputs"`
+		Injectors   map[string]interface{} `json:"injectors"`
+	}
+	err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/credential_types/%d/", id), nil, &credtype)
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -128,8 +534,51 @@ func resourceCredentialTypeRead(ctx context.Context, d *schema.ResourceData, m i
 	d.Set("name", credtype.Name)
 	d.Set("description", credtype.Description)
 	d.Set("kind", credtype.Kind)
-	d.Set("inputs", credtype.Inputs)
-	d.Set("injectors", credtype.Injectors)
+
+	if credtype.Inputs == nil {
+		credtype.Inputs = make(map[string]interface{})
+	}
+	if credtype.Injectors == nil {
+		credtype.Injectors = make(map[string]interface{})
+	}
+	inputs, _ := json.Marshal(credtype.Inputs)
+	d.Set("inputs", normalizeJsonYaml(string(inputs)))
+	var definition awxCredentialTypeInputs
+	json.Unmarshal(inputs, &definition)
+	fields := make([]interface{}, 0, len(definition.Fields))
+	for _, field := range definition.Fields {
+		fieldType := field.Type
+		if fieldType == "" {
+			fieldType = "string"
+		}
+		choices := make([]interface{}, 0, len(field.Choices))
+		for _, choice := range field.Choices {
+			choices = append(choices, fmt.Sprint(choice))
+		}
+		fields = append(fields, map[string]interface{}{
+			"id":        field.ID,
+			"label":     field.Label,
+			"type":      fieldType,
+			"secret":    field.Secret,
+			"multiline": field.Multiline,
+			"choices":   choices,
+			"help_text": field.HelpText,
+		})
+	}
+	d.Set("field", fields)
+	d.Set("required", definition.Required)
+
+	injectors, _ := json.Marshal(credtype.Injectors)
+	d.Set("injectors", normalizeJsonYaml(string(injectors)))
+	for _, key := range credentialTypeInjectors {
+		templates := make(map[string]interface{})
+		if values, ok := credtype.Injectors[key].(map[string]interface{}); ok {
+			for name, value := range values {
+				templates[name] = flattenCredentialTypeTemplate(value)
+			}
+		}
+		d.Set(key, templates)
+	}
 
 	return diags
 }
@@ -137,50 +586,26 @@ func resourceCredentialTypeRead(ctx context.Context, d *schema.ResourceData, m i
 func resourceCredentialTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	keys := []string{
+	keys := append([]string{
 		"name",
 		"description",
 		"kind",
 		"inputs",
 		"injectors",
-	}
+		"field",
+		"required",
+	}, credentialTypeInjectors...)
 
 	if d.HasChanges(keys...) {
-		var err error
-
-		inputs := d.Get("inputs").(string)
-		inputs_map := make(map[string]interface{})
-		inputs_jsonerr := json.Unmarshal([]byte(inputs), &inputs_map)
-		if inputs_jsonerr != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to create new credential",
-				Detail:   fmt.Sprintf("Unable to update credential type: %s", inputs_jsonerr.Error()),
-			})
-			return diags
-		}
-
-		injectors := d.Get("injectors").(string)
-		injectors_map := make(map[string]interface{})
-		injectors_jsonerr := json.Unmarshal([]byte(injectors), &injectors_map)
-		if injectors_jsonerr != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to create new credential",
-				Detail:   fmt.Sprintf("Unable to update credential type: %s", injectors_jsonerr.Error()),
-			})
-			return diags
+		updatedCredentialType, err := resourceCredentialTypePayload(d)
+		if err != nil {
+			return buildDiagnosticsMessage(
+				"Unable to update existing credential type",
+				"Unable to update credential type: %s", err.Error(),
+			)
 		}
 
 		id, _ := strconv.Atoi(d.Id())
-		updatedCredentialType := map[string]interface{}{
-			"name":        d.Get("name").(string),
-			"description": d.Get("description").(string),
-			"kind":        d.Get("kind").(string),
-			"inputs":      inputs_map,
-			"injectors":   injectors_map,
-		}
-
 		client := m.(*awx.AWX)
 		_, err = client.CredentialTypeService.UpdateCredentialTypeByID(id, updatedCredentialType, map[string]string{})
 		if err != nil {
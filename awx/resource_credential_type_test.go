@@ -0,0 +1,176 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testCredentialTypeConfig(region, env string) string {
+	return fmt.Sprintf(`
+resource "awx_credential_type" "test" {
+  name = "api"
+
+  field {
+    id     = "token"
+    label  = "Token"
+    secret = true
+  }
+
+  field {
+    id      = "region"
+    label   = "Region"
+    choices = [%q]
+  }
+
+  required = ["token"]
+
+  env = {
+%s
+  }
+
+  file = {
+    "template.config" = "region: {{ region }}"
+  }
+}
+`, region, env)
+}
+
+func TestResourceCredentialType(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_credential_type", "credential_types"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testCredentialTypeConfig("eu", `API_TOKEN = "{{ token }}"`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_credential_type.test", "field.1.type", "string"),
+					fake.checkField("awx_credential_type.test", "credential_types", "inputs", map[string]interface{}{
+						"fields": []interface{}{
+							map[string]interface{}{"id": "token", "label": "Token", "type": "string", "secret": true},
+							map[string]interface{}{"id": "region", "label": "Region", "type": "string", "choices": []interface{}{"eu"}},
+						},
+						"required": []interface{}{"token"},
+					}),
+					fake.checkField("awx_credential_type.test", "credential_types", "injectors", map[string]interface{}{
+						"env":  map[string]interface{}{"API_TOKEN": "{{ token }}"},
+						"file": map[string]interface{}{"template.config": "region: {{ region }}"},
+					}),
+				),
+			},
+			{
+				Config: fake.config(testCredentialTypeConfig("us", `API_CONFIG = "{{ tower.filename.config }}"`)),
+				Check: fake.checkField("awx_credential_type.test", "credential_types", "injectors", map[string]interface{}{
+					"env":  map[string]interface{}{"API_CONFIG": "{{ tower.filename.config }}"},
+					"file": map[string]interface{}{"template.config": "region: {{ region }}"},
+				}),
+			},
+			{
+				Config:      fake.config(testCredentialTypeConfig("us", `API_USER = "{{ username }}"`)),
+				ExpectError: regexp.MustCompile("env.API_USER references undefined field username"),
+			},
+			{
+				Config:      fake.config(testCredentialTypeConfig("us", `API_CERT = "{{ tower.filename.cert }}"`)),
+				ExpectError: regexp.MustCompile("env.API_CERT references undefined file template.cert"),
+			},
+			{
+				ResourceName:      "awx_credential_type.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Removed typed attributes are emptied.
+				Config: fake.config(`
+resource "awx_credential_type" "test" {
+  name = "api"
+
+  field {
+    id    = "token"
+    label = "Token"
+  }
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_credential_type.test", "field.#", "1"),
+					resource.TestCheckResourceAttr("awx_credential_type.test", "required.#", "0"),
+					resource.TestCheckResourceAttr("awx_credential_type.test", "env.%", "0"),
+					resource.TestCheckResourceAttr("awx_credential_type.test", "file.%", "0"),
+					fake.checkField("awx_credential_type.test", "credential_types", "inputs", map[string]interface{}{
+						"fields": []interface{}{
+							map[string]interface{}{"id": "token", "label": "Token", "type": "string"},
+						},
+					}),
+					fake.checkField("awx_credential_type.test", "credential_types", "injectors", map[string]interface{}{}),
+				),
+			},
+			{
+				// The raw documents are still accepted, whitespace does not
+				// show a diff.
+				Config: fake.config(`
+resource "awx_credential_type" "test" {
+  name      = "api"
+  inputs    = "{\"fields\": [{\"id\": \"token\", \"label\": \"Token\", \"type\": \"string\"}]}"
+  injectors = "{ \"env\": { \"API_TOKEN\": \"{{ token }}\" } }"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_credential_type.test", "field.#", "1"),
+					resource.TestCheckResourceAttr("awx_credential_type.test", "env.API_TOKEN", "{{ token }}"),
+					resource.TestCheckResourceAttr("awx_credential_type.test", "file.%", "0"),
+				),
+			},
+			{
+				Config: fake.config(`
+resource "awx_credential_type" "test" {
+  name      = "api"
+  inputs    = "{\"fields\":[{\"id\":\"token\",\"label\":\"Token\",\"type\":\"string\"}]}"
+  injectors = "{\"env\":{\"API_TOKEN\":\"{{ token }}\"}}"
+}
+`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestValidateCredentialType(t *testing.T) {
+	inputs := map[string]interface{}{
+		"fields": []interface{}{
+			map[string]interface{}{"id": "token", "label": "Token"},
+			map[string]interface{}{"id": "token", "label": "Token again"},
+		},
+		"required": []interface{}{"token", "region"},
+	}
+	injectors := map[string]interface{}{
+		"env": map[string]interface{}{
+			"API_TOKEN":  "{{ token | default('') }}",
+			"API_REGION": "{{region}}",
+		},
+		"extra_vars": map[string]interface{}{
+			"api_config": "{{ awx.filename }}",
+			"api_home":   "{{ tower.home }}",
+		},
+	}
+	err := validateCredentialType(inputs, injectors)
+	if err == nil {
+		t.Fatal("invalid credential type, got no error")
+	}
+	for _, want := range []string{
+		"field token is defined more than once",
+		"required field region is not defined",
+		"env.API_REGION references undefined field region",
+		"extra_vars.api_config references undefined file template",
+		"extra_vars.api_home references unknown variable tower.home",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "API_TOKEN") {
+		t.Errorf("error %q reports the valid API_TOKEN template", err)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+`awx_credential_vault` manages Vault credentials, the password used to decrypt ansible-vault encrypted content.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_vault" "prod" {
+	  name            = "vault-prod"
+	  organization_id = awx_organization.default.id
+	  vault_password  = var.vault_password
+	  vault_id        = "prod"
+	}
+
+```
+
+# Import
+
+Credentials can be imported using the credential ID or the name path
+`<organization>/<name>`. Secret inputs are not returned by AWX and have to
+be set in the configuration.
+
+```sh
+terraform import awx_credential_vault.prod 7
+terraform import awx_credential_vault.prod Default/my-credential
+```
+*/
+package awx
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var credentialKindVault = &credentialKind{
+	name:      "Vault",
+	namespace: "vault",
+	inputs: []credentialInput{
+		{attribute: "vault_password", required: true, secret: true, description: "Password of the ansible-vault"},
+		{
+			attribute:   "vault_id",
+			description: "Vault identifier of the password, as given to ansible-vault --vault-id",
+			validate:    validation.StringMatch(regexp.MustCompile(`^[^@\s]*$`), "must not contain whitespace or @"),
+		},
+	},
+}
+
+func resourceCredentialVault() *schema.Resource {
+	return resourceCredentialKind(credentialKindVault)
+}
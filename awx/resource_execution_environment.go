@@ -0,0 +1,195 @@
+/*
+*TBD*
+
+# Example Usage
+
+```hcl
+
+	resource "awx_execution_environment" "ci" {
+	  name            = "ci-ee"
+	  image           = "registry.example.com/awx/ci-ee:${var.ee_tag}"
+	  pull            = "always"
+	  organization_id = awx_organization.default.id
+	  credential_id   = awx_credential.registry.id
+	}
+
+	resource "awx_project" "playbooks" {
+	  name                   = "playbooks"
+	  scm_type               = "git"
+	  scm_url                = "https://github.com/example/playbooks"
+	  organization_id        = awx_organization.default.id
+	  default_environment_id = awx_execution_environment.ci.id
+	}
+
+```
+
+# Import
+
+Execution environments can be imported using the execution environment ID or
+the name.
+
+```sh
+terraform import awx_execution_environment.ci 5
+terraform import awx_execution_environment.ci ci-ee
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// awxExecutionEnvironment is the subset of the AWX execution environment
+// object managed by the provider.
+type awxExecutionEnvironment struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Image        string `json:"image"`
+	Pull         string `json:"pull"`
+	Organization int    `json:"organization"`
+	Credential   int    `json:"credential"`
+}
+
+func resourceExecutionEnvironment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceExecutionEnvironmentCreate,
+		ReadContext:   resourceExecutionEnvironmentRead,
+		UpdateContext: resourceExecutionEnvironmentUpdate,
+		DeleteContext: resourceExecutionEnvironmentDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of this execution environment",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Optional description of this execution environment.",
+			},
+			"image": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Full image location, including the container registry, image name and version tag",
+			},
+			"pull": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.StringInSlice([]string{"", "always", "missing", "never"}, false),
+				Description:  "Pull the image before running, one of always, missing or never",
+			},
+			"organization_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Numeric ID of the organization allowed to use the execution environment, available to all organizations if not set",
+			},
+			"credential_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Numeric ID of the container registry credential used to pull the image",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathExecutionEnvironment),
+		},
+	}
+}
+
+func executionEnvironmentPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"name":         d.Get("name").(string),
+		"description":  d.Get("description").(string),
+		"image":        d.Get("image").(string),
+		"pull":         d.Get("pull").(string),
+		"organization": optionalID(d.Get("organization_id").(int)),
+		"credential":   optionalID(d.Get("credential_id").(int)),
+	}
+}
+
+func resourceExecutionEnvironmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var ee awxExecutionEnvironment
+	if err := getAPIClient(m).post(ctx, "/api/v2/execution_environments/", executionEnvironmentPayload(d), &ee); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: ExecutionEnvironment not created",
+			"ExecutionEnvironment with name %s not created, %s",
+			d.Get("name").(string), err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(ee.ID))
+	return resourceExecutionEnvironmentRead(ctx, d, m)
+}
+
+func resourceExecutionEnvironmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Update ExecutionEnvironment", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := getAPIClient(m).patch(ctx, fmt.Sprintf("/api/v2/execution_environments/%d/", id), executionEnvironmentPayload(d), nil)
+	if err != nil {
+		return buildDiagUpdateFail("execution environment", id, err)
+	}
+	return resourceExecutionEnvironmentRead(ctx, d, m)
+}
+
+func resourceExecutionEnvironmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read ExecutionEnvironment", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	var ee awxExecutionEnvironment
+	err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/execution_environments/%d/", id), nil, &ee)
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return buildDiagNotFoundFail("execution environment", id, err)
+	}
+
+	d.Set("name", ee.Name)
+	d.Set("description", ee.Description)
+	d.Set("image", ee.Image)
+	d.Set("pull", ee.Pull)
+	d.Set("organization_id", ee.Organization)
+	d.Set("credential_id", ee.Credential)
+	return diags
+}
+
+func resourceExecutionEnvironmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Delete ExecutionEnvironment", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := getAPIClient(m).delete(ctx, fmt.Sprintf("/api/v2/execution_environments/%d/", id))
+	if err != nil && !isNotFound(err) {
+		return buildDiagDeleteFail("ExecutionEnvironment", fmt.Sprintf("ExecutionEnvironmentID %v, got %s ", id, err.Error()))
+	}
+	d.SetId("")
+	return diags
+}
+
+// setDefaultEnvironmentResourceData reads the default execution environment
+// of a project or organization, which goawx does not expose.
+func setDefaultEnvironmentResourceData(ctx context.Context, d *schema.ResourceData, m interface{}, collection string, id int) error {
+	var obj struct {
+		DefaultEnvironment int `json:"default_environment"`
+	}
+	if err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/%s/%d/", collection, id), nil, &obj); err != nil {
+		return err
+	}
+	return d.Set("default_environment_id", obj.DefaultEnvironment)
+}
@@ -0,0 +1,95 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testExecutionEnvironmentConfig(tag string) string {
+	return testJobTemplateConfig("hello_world.yml") + fmt.Sprintf(`
+resource "awx_execution_environment" "test" {
+  name            = "test-ee"
+  image           = "registry.example.com/awx/ci-ee:%s"
+  pull            = "always"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_project" "ee" {
+  name                   = "test-ee-project"
+  scm_type               = "git"
+  scm_url                = "https://github.com/ansible/ansible-tower-samples"
+  organization_id        = awx_organization.test.id
+  default_environment_id = awx_execution_environment.test.id
+}
+
+resource "awx_organization" "ee" {
+  name                   = "test-ee-org"
+  default_environment_id = awx_execution_environment.test.id
+}
+
+resource "awx_job_template" "ee" {
+  name                     = "test-ee-job-template"
+  job_type                 = "run"
+  inventory_id             = awx_inventory.test.id
+  project_id               = awx_project.test.id
+  playbook                 = "hello_world.yml"
+  execution_environment_id = awx_execution_environment.test.id
+}
+`, tag)
+}
+
+// testCheckSameID verifies that the ID of a resource does not change across
+// test steps, i.e. that the resource was updated in place.
+func testCheckSameID(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		if *id == "" {
+			*id = rs.Primary.ID
+		}
+		if rs.Primary.ID != *id {
+			return fmt.Errorf("expected %s to keep ID %s, got %s", name, *id, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func TestResourceExecutionEnvironment(t *testing.T) {
+	fake := newFakeAWX(t)
+	var id string
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_execution_environment", "execution_environments"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testExecutionEnvironmentConfig("1.0")),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSameID("awx_execution_environment.test", &id),
+					resource.TestCheckResourceAttr("awx_execution_environment.test", "pull", "always"),
+					resource.TestCheckResourceAttrPair("awx_project.ee", "default_environment_id", "awx_execution_environment.test", "id"),
+					resource.TestCheckResourceAttrPair("awx_organization.ee", "default_environment_id", "awx_execution_environment.test", "id"),
+					resource.TestCheckResourceAttrPair("awx_job_template.ee", "execution_environment_id", "awx_execution_environment.test", "id"),
+					fake.checkField("awx_execution_environment.test", "execution_environments", "image", "registry.example.com/awx/ci-ee:1.0"),
+				),
+			},
+			{
+				Config: fake.config(testExecutionEnvironmentConfig("1.1")),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSameID("awx_execution_environment.test", &id),
+					fake.checkField("awx_execution_environment.test", "execution_environments", "image", "registry.example.com/awx/ci-ee:1.1"),
+				),
+			},
+			{
+				ResourceName:      "awx_execution_environment.test",
+				ImportState:       true,
+				ImportStateId:     "test-ee",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -21,6 +21,16 @@ ansible_host: 192.168.178.29
 YAML
 }
 ```
+
+# Import
+
+Hosts can be imported using the host ID or the name path
+`<organization>/<inventory>/<name>`.
+
+```sh
+terraform import awx_host.k3snode1 21
+terraform import awx_host.k3snode1 Default/my-inventory/web01
+```
 */
 package awx
 
@@ -78,7 +88,7 @@ func resourceHost() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByNamePath(namePathHost),
 		},
 	}
 }
@@ -0,0 +1,76 @@
+package awx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testHostBaseConfig = `
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_inventory" "test" {
+  name            = "test-inventory"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_inventory_group" "test" {
+  name         = "test-group"
+  inventory_id = awx_inventory.test.id
+}
+`
+
+func TestResourceHost(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_host", "hosts"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testHostBaseConfig + `
+resource "awx_host" "test" {
+  name         = "web01"
+  inventory_id = awx_inventory.test.id
+  group_ids    = [awx_inventory_group.test.id]
+  enabled      = true
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_host.test", "name", "web01"),
+					resource.TestCheckResourceAttr("awx_host.test", "group_ids.#", "1"),
+					fake.checkField("awx_host.test", "hosts", "enabled", true),
+				),
+			},
+			{
+				Config: fake.config(testHostBaseConfig + `
+resource "awx_host" "test" {
+  name         = "web01"
+  description  = "updated"
+  inventory_id = awx_inventory.test.id
+  group_ids    = [awx_inventory_group.test.id]
+  enabled      = false
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_host.test", "description", "updated"),
+					fake.checkField("awx_host.test", "hosts", "enabled", false),
+				),
+			},
+			{
+				ResourceName:            "awx_host.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"group_ids"},
+			},
+			{
+				ResourceName:            "awx_host.test",
+				ImportState:             true,
+				ImportStateId:           "test-org/test-inventory/web01",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"group_ids"},
+			},
+		},
+	})
+}
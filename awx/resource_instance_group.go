@@ -0,0 +1,303 @@
+/*
+*TBD*
+
+# Example Usage
+
+```hcl
+
+	resource "awx_instance_group" "batch" {
+	  name                       = "batch"
+	  policy_instance_percentage = 50
+	  policy_instance_minimum    = 2
+	}
+
+	resource "awx_instance_group" "k8s" {
+	  name               = "k8s"
+	  is_container_group = true
+	  credential_id      = awx_credential.openshift.id
+	  pod_spec_override  = file("${path.module}/pod_spec.yml")
+	}
+
+	resource "awx_job_template" "report" {
+	  name               = "report"
+	  job_type           = "run"
+	  inventory_id       = awx_inventory.default.id
+	  project_id         = awx_project.default.id
+	  playbook           = "report.yml"
+	  instance_group_ids = [awx_instance_group.k8s.id, awx_instance_group.batch.id]
+	}
+
+```
+
+# Import
+
+Instance groups can be imported using the instance group ID or the name.
+
+```sh
+terraform import awx_instance_group.batch 3
+terraform import awx_instance_group.batch batch
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// awxInstanceGroup is the subset of the AWX instance group object managed by
+// the provider.
+type awxInstanceGroup struct {
+	ID                       int    `json:"id"`
+	Name                     string `json:"name"`
+	IsContainerGroup         bool   `json:"is_container_group"`
+	Credential               int    `json:"credential"`
+	PodSpecOverride          string `json:"pod_spec_override"`
+	PolicyInstancePercentage int    `json:"policy_instance_percentage"`
+	PolicyInstanceMinimum    int    `json:"policy_instance_minimum"`
+}
+
+// instanceGroupIDsSchema is the ordered list of instance groups of a job
+// template, inventory or organization. AWX tries the instance groups in this
+// order. When it is not configured the instance groups set in AWX are left
+// alone.
+var instanceGroupIDsSchema = &schema.Schema{
+	Type:        schema.TypeList,
+	Elem:        &schema.Schema{Type: schema.TypeInt},
+	Optional:    true,
+	Computed:    true,
+	Description: "Numeric IDs of the instance groups jobs run on, in the order AWX falls back to them, the instance groups are not managed when unset",
+}
+
+// customizeDiffInstanceGroupIDs removes all instance groups of an object
+// configured with instance_group_ids = [].
+var customizeDiffInstanceGroupIDs = customizeDiffConfiguredEmpty("instance_group_ids", []interface{}{})
+
+func resourceInstanceGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceInstanceGroupCreate,
+		ReadContext:   resourceInstanceGroupRead,
+		UpdateContext: resourceInstanceGroupUpdate,
+		DeleteContext: resourceInstanceGroupDelete,
+		CustomizeDiff: resourceInstanceGroupCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of this instance group",
+			},
+			"is_container_group": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether jobs run in pods of a Kubernetes or OpenShift cluster",
+			},
+			"credential_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Numeric ID of the OpenShift or Kubernetes API bearer token credential of a container group",
+			},
+			"pod_spec_override": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Custom pod spec of a container group in YAML or JSON",
+			},
+			"policy_instance_percentage": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 100),
+				Description:  "Minimum percentage of all instances automatically assigned to this group",
+			},
+			"policy_instance_minimum": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Minimum number of instances automatically assigned to this group",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathInstanceGroup),
+		},
+	}
+}
+
+// resourceInstanceGroupCustomizeDiff rejects settings which do not apply to
+// the kind of instance group at plan time.
+func resourceInstanceGroupCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("is_container_group").(bool) {
+		for _, key := range []string{"policy_instance_percentage", "policy_instance_minimum"} {
+			if d.Get(key).(int) != 0 {
+				return fmt.Errorf("%s is not supported by container groups", key)
+			}
+		}
+		return nil
+	}
+	if d.Get("credential_id").(int) != 0 || !d.NewValueKnown("credential_id") {
+		return fmt.Errorf("credential_id requires is_container_group to be set")
+	}
+	if d.Get("pod_spec_override").(string) != "" || !d.NewValueKnown("pod_spec_override") {
+		return fmt.Errorf("pod_spec_override requires is_container_group to be set")
+	}
+	return nil
+}
+
+func instanceGroupPayload(d *schema.ResourceData) map[string]interface{} {
+	payload := map[string]interface{}{
+		"name": d.Get("name").(string),
+	}
+	if d.Get("is_container_group").(bool) {
+		payload["credential"] = nil
+		if credentialID := d.Get("credential_id").(int); credentialID > 0 {
+			payload["credential"] = credentialID
+		}
+		payload["pod_spec_override"] = d.Get("pod_spec_override").(string)
+	} else {
+		payload["policy_instance_percentage"] = d.Get("policy_instance_percentage").(int)
+		payload["policy_instance_minimum"] = d.Get("policy_instance_minimum").(int)
+	}
+	return payload
+}
+
+func resourceInstanceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	payload := instanceGroupPayload(d)
+	payload["is_container_group"] = d.Get("is_container_group").(bool)
+
+	var group awxInstanceGroup
+	if err := getAPIClient(m).post(ctx, "/api/v2/instance_groups/", payload, &group); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: InstanceGroup not created",
+			"InstanceGroup with name %s not created, %s",
+			d.Get("name").(string), err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(group.ID))
+	return resourceInstanceGroupRead(ctx, d, m)
+}
+
+func resourceInstanceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Update InstanceGroup", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := getAPIClient(m).patch(ctx, fmt.Sprintf("/api/v2/instance_groups/%d/", id), instanceGroupPayload(d), nil); err != nil {
+		return buildDiagUpdateFail("instance group", id, err)
+	}
+	return resourceInstanceGroupRead(ctx, d, m)
+}
+
+func resourceInstanceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read InstanceGroup", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	var group awxInstanceGroup
+	err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/instance_groups/%d/", id), nil, &group)
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return buildDiagNotFoundFail("instance group", id, err)
+	}
+
+	d.Set("name", group.Name)
+	d.Set("is_container_group", group.IsContainerGroup)
+	d.Set("credential_id", group.Credential)
+	d.Set("pod_spec_override", group.PodSpecOverride)
+	d.Set("policy_instance_percentage", group.PolicyInstancePercentage)
+	d.Set("policy_instance_minimum", group.PolicyInstanceMinimum)
+	return diags
+}
+
+func resourceInstanceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Delete InstanceGroup", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := getAPIClient(m).delete(ctx, fmt.Sprintf("/api/v2/instance_groups/%d/", id))
+	if err != nil && !isNotFound(err) {
+		return buildDiagDeleteFail("InstanceGroup", fmt.Sprintf("InstanceGroupID %v, got %s ", id, err.Error()))
+	}
+	d.SetId("")
+	return diags
+}
+
+// instanceGroupChanges returns the instance groups to disassociate and to
+// associate, in this order, to turn the list current into the list wanted. AWX
+// appends associated instance groups, so everything behind the common
+// prefix of both lists is removed and added again in the new order.
+func instanceGroupChanges(current, wanted []int) (remove, add []int) {
+	prefix := 0
+	for prefix < len(current) && prefix < len(wanted) && current[prefix] == wanted[prefix] {
+		prefix++
+	}
+	return current[prefix:], wanted[prefix:]
+}
+
+// instanceGroupsUpdate applies a changed instance_group_ids list through the
+// /instance_groups/ endpoint of a job template, inventory or organization.
+func instanceGroupsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}, collection string, id int) error {
+	if !d.HasChange("instance_group_ids") {
+		return nil
+	}
+	o, n := d.GetChange("instance_group_ids")
+	var current, wanted []int
+	for _, v := range o.([]interface{}) {
+		current = append(current, v.(int))
+	}
+	for _, v := range n.([]interface{}) {
+		wanted = append(wanted, v.(int))
+	}
+
+	path := fmt.Sprintf("/api/v2/%s/%d/instance_groups/", collection, id)
+	remove, add := instanceGroupChanges(current, wanted)
+	for _, groupID := range remove {
+		payload := map[string]interface{}{
+			"id":           groupID,
+			"disassociate": true, // presence of key triggers removal
+		}
+		if err := getAPIClient(m).post(ctx, path, payload, nil); err != nil {
+			return err
+		}
+	}
+	for _, groupID := range add {
+		payload := map[string]interface{}{
+			"id": groupID,
+		}
+		if err := getAPIClient(m).post(ctx, path, payload, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setInstanceGroupsResourceData reads the ordered instance groups of a job
+// template, inventory or organization.
+func setInstanceGroupsResourceData(ctx context.Context, d *schema.ResourceData, m interface{}, collection string, id int) error {
+	groups, err := getAPIClient(m).list(ctx, fmt.Sprintf("/api/v2/%s/%d/instance_groups/", collection, id), nil)
+	if err != nil {
+		return err
+	}
+
+	var groupIDs []interface{}
+	for _, g := range groups {
+		if id, ok := g["id"].(float64); ok {
+			groupIDs = append(groupIDs, int(id))
+		}
+	}
+	return d.Set("instance_group_ids", groupIDs)
+}
@@ -0,0 +1,155 @@
+package awx
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testInstanceGroupConfig(instanceGroupIDs string) string {
+	return testJobTemplateConfig("hello_world.yml") + fmt.Sprintf(`
+resource "awx_instance_group" "batch" {
+  name                       = "batch"
+  policy_instance_percentage = 50
+  policy_instance_minimum    = 2
+}
+
+resource "awx_instance_group" "k8s" {
+  name               = "k8s"
+  is_container_group = true
+  pod_spec_override  = "apiVersion: v1\nkind: Pod\n"
+}
+
+resource "awx_job_template" "routed" {
+  name               = "test-routed"
+  job_type           = "run"
+  inventory_id       = awx_inventory.test.id
+  project_id         = awx_project.test.id
+  playbook           = "hello_world.yml"
+  instance_group_ids = %s
+}
+
+resource "awx_inventory" "routed" {
+  name               = "test-routed"
+  organization_id    = awx_organization.test.id
+  instance_group_ids = [awx_instance_group.batch.id]
+}
+`, instanceGroupIDs)
+}
+
+func TestResourceInstanceGroup(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_instance_group", "instance_groups"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(`
+resource "awx_instance_group" "invalid" {
+  name                    = "invalid"
+  is_container_group      = true
+  policy_instance_minimum = 1
+}
+`),
+				ExpectError: regexp.MustCompile(`policy_instance_minimum is not supported by container groups`),
+			},
+			{
+				Config: fake.config(testInstanceGroupConfig("[awx_instance_group.k8s.id, awx_instance_group.batch.id]")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_instance_group.batch", "policy_instance_percentage", "50"),
+					resource.TestCheckResourceAttr("awx_instance_group.k8s", "is_container_group", "true"),
+					resource.TestCheckResourceAttr("awx_job_template.routed", "instance_group_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("awx_job_template.routed", "instance_group_ids.0", "awx_instance_group.k8s", "id"),
+					resource.TestCheckResourceAttrPair("awx_job_template.routed", "instance_group_ids.1", "awx_instance_group.batch", "id"),
+					fake.checkRelated("awx_inventory.routed", "inventories", "instance_groups", "awx_instance_group.batch"),
+				),
+			},
+			{
+				Config: fake.config(testInstanceGroupConfig("[awx_instance_group.batch.id, awx_instance_group.k8s.id]")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("awx_job_template.routed", "instance_group_ids.0", "awx_instance_group.batch", "id"),
+					resource.TestCheckResourceAttrPair("awx_job_template.routed", "instance_group_ids.1", "awx_instance_group.k8s", "id"),
+				),
+			},
+			{
+				ResourceName:      "awx_instance_group.k8s",
+				ImportState:       true,
+				ImportStateId:     "k8s",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_job_template.routed",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testInstanceGroupOrganizationConfig(instanceGroupIDs string) string {
+	return fmt.Sprintf(`
+resource "awx_instance_group" "batch" {
+  name = "batch"
+}
+
+resource "awx_organization" "test" {
+  name = "test-org"
+  %s
+}
+`, instanceGroupIDs)
+}
+
+func TestResourceInstanceGroupUnmanaged(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				// Instance groups set in AWX are kept without
+				// instance_group_ids.
+				Config: fake.config(testInstanceGroupOrganizationConfig("")),
+				Check:  fake.associate("awx_organization.test", "organizations", "instance_groups", "awx_instance_group.batch"),
+			},
+			{
+				Config:   fake.config(testInstanceGroupOrganizationConfig("")),
+				PlanOnly: true,
+			},
+			{
+				Config: fake.config(testInstanceGroupOrganizationConfig("")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_organization.test", "instance_group_ids.#", "1"),
+					fake.checkRelated("awx_organization.test", "organizations", "instance_groups", "awx_instance_group.batch"),
+				),
+			},
+			{
+				Config: fake.config(testInstanceGroupOrganizationConfig("instance_group_ids = []")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_organization.test", "instance_group_ids.#", "0"),
+					fake.checkNotRelated("awx_organization.test", "organizations", "instance_groups", "awx_instance_group.batch"),
+				),
+			},
+		},
+	})
+}
+
+func TestInstanceGroupChanges(t *testing.T) {
+	cases := []struct {
+		current, wanted, remove, add []int
+	}{
+		{nil, []int{1, 2}, nil, []int{1, 2}},
+		{[]int{1, 2}, []int{1, 2, 3}, nil, []int{3}},
+		{[]int{1, 2, 3}, []int{1, 3}, []int{2, 3}, []int{3}},
+		{[]int{1, 2}, []int{2, 1}, []int{1, 2}, []int{2, 1}},
+		{[]int{1, 2}, nil, []int{1, 2}, nil},
+	}
+	for _, c := range cases {
+		remove, add := instanceGroupChanges(c.current, c.wanted)
+		if len(remove) != len(c.remove) || len(add) != len(c.add) ||
+			(len(remove) > 0 && !reflect.DeepEqual(remove, c.remove)) || (len(add) > 0 && !reflect.DeepEqual(add, c.add)) {
+			t.Errorf("instanceGroupChanges(%v, %v) = %v, %v, want %v, %v", c.current, c.wanted, remove, add, c.remove, c.add)
+		}
+	}
+}
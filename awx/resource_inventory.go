@@ -21,6 +21,16 @@ system_supporters:
 YAML
 }
 ```
+
+# Import
+
+Inventories can be imported using the inventory ID or the name path
+`<organization>/<name>`.
+
+```sh
+terraform import awx_inventory.default 5
+terraform import awx_inventory.default Default/my-inventory
+```
 */
 package awx
 
@@ -40,6 +50,7 @@ func resourceInventory() *schema.Resource {
 		ReadContext:   resourceInventoryRead,
 		DeleteContext: resourceInventoryDelete,
 		UpdateContext: resourceInventoryUpdate,
+		CustomizeDiff: customizeDiffInstanceGroupIDs,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -71,9 +82,10 @@ func resourceInventory() *schema.Resource {
 				Default:   "",
 				StateFunc: normalizeJsonYaml,
 			},
+			"instance_group_ids": instanceGroupIDsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByNamePath(namePathInventory),
 		},
 	}
 }
@@ -95,6 +107,9 @@ func resourceInventoryCreate(ctx context.Context, d *schema.ResourceData, m inte
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
+	if err := instanceGroupsUpdate(ctx, d, m, "inventories", result.ID); err != nil {
+		return buildDiagCreateFail("inventory instance groups", err)
+	}
 	return resourceInventoryRead(ctx, d, m)
 
 }
@@ -118,6 +133,9 @@ func resourceInventoryUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		return buildDiagUpdateFail(diagElementInventoryTitle, id, err)
 	}
 
+	if err := instanceGroupsUpdate(ctx, d, m, "inventories", id); err != nil {
+		return buildDiagUpdateFail("inventory instance groups", id, err)
+	}
 	return resourceInventoryRead(ctx, d, m)
 
 }
@@ -135,6 +153,9 @@ func resourceInventoryRead(ctx context.Context, d *schema.ResourceData, m interf
 		return buildDiagNotFoundFail(diagElementInventoryTitle, id, err)
 	}
 	d = setInventoryResourceData(d, r)
+	if err := setInstanceGroupsResourceData(ctx, d, m, "inventories", id); err != nil {
+		return buildDiagNotFoundFail("inventory instance groups", id, err)
+	}
 	return nil
 }
 
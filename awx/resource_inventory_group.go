@@ -6,6 +6,16 @@
 ```hcl
 *TBD*
 ```
+
+# Import
+
+Inventory groups can be imported using the group ID or the name path
+`<organization>/<inventory>/<name>`.
+
+```sh
+terraform import awx_inventory_group.example 9
+terraform import awx_inventory_group.example Default/my-inventory/webservers
+```
 */
 package awx
 
@@ -49,7 +59,7 @@ func resourceInventoryGroup() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByNamePath(namePathInventoryGroup),
 		},
 	}
 }
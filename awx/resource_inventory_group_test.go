@@ -0,0 +1,63 @@
+package awx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceInventoryGroup(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_inventory_group", "groups"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_inventory" "test" {
+  name            = "test-inventory"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_inventory_group" "test" {
+  name         = "test-group"
+  inventory_id = awx_inventory.test.id
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_inventory_group.test", "name", "test-group"),
+					fake.checkField("awx_inventory_group.test", "groups", "name", "test-group"),
+				),
+			},
+			{
+				Config: fake.config(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_inventory" "test" {
+  name            = "test-inventory"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_inventory_group" "test" {
+  name         = "test-group"
+  description  = "updated"
+  inventory_id = awx_inventory.test.id
+}
+`),
+				Check: fake.checkField("awx_inventory_group.test", "groups", "description", "updated"),
+			},
+			{
+				ResourceName:            "awx_inventory_group.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"inventory_id"},
+			},
+		},
+	})
+}
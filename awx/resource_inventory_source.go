@@ -6,6 +6,16 @@
 ```hcl
 *TBD*
 ```
+
+# Import
+
+Inventory sources can be imported using the inventory source ID or the name path
+`<organization>/<inventory>/<name>`.
+
+```sh
+terraform import awx_inventory_source.example 11
+terraform import awx_inventory_source.example Default/my-inventory/my-source
+```
 */
 package awx
 
@@ -106,7 +116,7 @@ func resourceInventorySource() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByNamePath(namePathInventorySource),
 		},
 	}
 }
@@ -0,0 +1,95 @@
+package awx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceInventory(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_inventory", "inventories"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_inventory" "test" {
+  name            = "test-inventory"
+  organization_id = awx_organization.test.id
+  variables       = <<YAML
+---
+foo: bar
+YAML
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_inventory.test", "name", "test-inventory"),
+					resource.TestCheckResourceAttrPair("awx_inventory.test", "organization_id", "awx_organization.test", "id"),
+				),
+			},
+			{
+				Config: fake.config(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_inventory" "test" {
+  name            = "test-inventory"
+  description     = "updated"
+  organization_id = awx_organization.test.id
+  variables       = <<YAML
+---
+foo: baz
+YAML
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_inventory.test", "description", "updated"),
+					fake.checkField("awx_inventory.test", "inventories", "description", "updated"),
+				),
+			},
+			{
+				ResourceName:      "awx_inventory.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_inventory.test",
+				ImportState:       true,
+				ImportStateId:     "test-org/test-inventory",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "awx_inventory.test",
+				ImportState:   true,
+				ImportStateId: "test-org/missing",
+				ExpectError:   regexp.MustCompile(`no object found for "test-org/missing"`),
+			},
+			{
+				// The name of the object may contain the separator.
+				Config: fake.config(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_inventory" "test" {
+  name            = "test/inventory"
+  organization_id = awx_organization.test.id
+}
+`),
+			},
+			{
+				ResourceName:      "awx_inventory.test",
+				ImportState:       true,
+				ImportStateId:     "test-org/test/inventory",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -17,9 +17,42 @@
 	  project_id     = awx_project.base_service_config.id
 	  playbook       = "master-configure-system.yml"
 	  become_enabled = true
+	  survey_enabled = true
+
+	  survey {
+	    name = "baseconfig"
+
+	    question {
+	      question_name = "Environment"
+	      variable      = "environment"
+	      type          = "multiplechoice"
+	      choices       = ["staging", "production"]
+	      default       = "staging"
+	      required      = true
+	    }
+
+	    question {
+	      question_name = "Parallel hosts"
+	      variable      = "serial"
+	      type          = "integer"
+	      min           = 1
+	      max           = 50
+	      default       = "10"
+	    }
+	  }
 	}
 
 ```
+
+# Import
+
+Job templates can be imported using the job template ID or the name path
+`<organization>/<name>`.
+
+```sh
+terraform import awx_job_template.baseconfig 12
+terraform import awx_job_template.baseconfig Default/my-jt
+```
 */
 package awx
 
@@ -30,6 +63,7 @@ import (
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	awx "github.com/mrcrilly/goawx/client"
 )
@@ -116,10 +150,12 @@ func resourceJobTemplate() *schema.Resource {
 			"organization_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 			},
 			"execution_environment_id": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Numeric ID of the execution environment jobs run in",
 			},
 			"host_config_key": {
 				Type:     schema.TypeString,
@@ -193,6 +229,9 @@ func resourceJobTemplate() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"survey":             surveySchema,
+			"labels":             labelsSchema,
+			"instance_group_ids": instanceGroupIDsSchema,
 			"become_enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -230,10 +269,11 @@ func resourceJobTemplate() *schema.Resource {
 				Optional: true,
 			},
 		},
-		//Importer: &schema.ResourceImporter{
-		//	State: schema.ImportStatePassthrough,
-		//},
-		//
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathJobTemplate),
+		},
+		CustomizeDiff: customdiff.All(customizeDiffSurvey, customizeDiffLabels, customizeDiffInstanceGroupIDs),
+
 		//Timeouts: &schema.ResourceTimeout{
 		//	Create: schema.DefaultTimeout(1 * time.Minute),
 		//	Update: schema.DefaultTimeout(1 * time.Minute),
@@ -294,7 +334,7 @@ func resourceJobTemplateCreate(ctx context.Context, d *schema.ResourceData, m in
 		params["organization_id"] = p.(int)
 	}
 	if p, ok := d.GetOk("execution_environment_id"); ok {
-		params["execution_environment_id"] = p.(int)
+		params["execution_environment"] = p.(int)
 	}
 	if p, ok := d.GetOk("host_config_key"); ok {
 		params["host_config_key"] = p.(string)
@@ -338,8 +378,8 @@ func resourceJobTemplateCreate(ctx context.Context, d *schema.ResourceData, m in
 	if p, ok := d.GetOk("ask_forks_on_launch"); ok {
 		params["ask_forks_on_launch"] = p.(bool)
 	}
-	if p, ok := d.GetOk("ask_job_slice_on_launch"); ok {
-		params["ask_job_slice_on_launch"] = p.(bool)
+	if p, ok := d.GetOk("ask_job_slice_count_on_launch"); ok {
+		params["ask_job_slice_count_on_launch"] = p.(bool)
 	}
 	if p, ok := d.GetOk("ask_timeout_on_launch"); ok {
 		params["ask_timeout_on_launch"] = p.(bool)
@@ -390,6 +430,29 @@ func resourceJobTemplateCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
+	if err := instanceGroupsUpdate(ctx, d, m, "job_templates", result.ID); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: JobTemplate instance groups not attached",
+			"Instance groups of JobTemplate with ID %v not attached, %s",
+			result.ID, err.Error(),
+		)
+	}
+	if err := labelsUpdate(ctx, d, m, "job_templates", result.ID); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: JobTemplate labels not attached",
+			"Labels of JobTemplate with ID %v not attached, %s",
+			result.ID, err.Error(),
+		)
+	}
+	if _, ok := d.GetOk("survey"); ok {
+		if err := surveySpecUpdate(ctx, d, m, "job_templates", result.ID); err != nil {
+			return buildDiagnosticsMessage(
+				"Create: JobTemplate survey not created",
+				"Survey of JobTemplate with ID %v not created, %s",
+				result.ID, err.Error(),
+			)
+		}
+	}
 	return resourceJobTemplateRead(ctx, d, m)
 }
 
@@ -454,9 +517,7 @@ func resourceJobTemplateUpdate(ctx context.Context, d *schema.ResourceData, m in
 	if p, ok := d.GetOk("organization_id"); ok {
 		params["organization_id"] = p.(int)
 	}
-	if p, ok := d.GetOk("execution_environment_id"); ok {
-		params["execution_environment_id"] = p.(int)
-	}
+	params["execution_environment"] = optionalID(d.Get("execution_environment_id").(int))
 	if p, ok := d.GetOk("host_config_key"); ok {
 		params["host_config_key"] = p.(string)
 	}
@@ -499,8 +560,8 @@ func resourceJobTemplateUpdate(ctx context.Context, d *schema.ResourceData, m in
 	if p, ok := d.GetOk("ask_forks_on_launch"); ok {
 		params["ask_forks_on_launch"] = p.(bool)
 	}
-	if p, ok := d.GetOk("ask_job_slice_on_launch"); ok {
-		params["ask_job_slice_on_launch"] = p.(bool)
+	if p, ok := d.GetOk("ask_job_slice_count_on_launch"); ok {
+		params["ask_job_slice_count_on_launch"] = p.(bool)
 	}
 	if p, ok := d.GetOk("ask_timeout_on_launch"); ok {
 		params["ask_timeout_on_launch"] = p.(bool)
@@ -549,6 +610,17 @@ func resourceJobTemplateUpdate(ctx context.Context, d *schema.ResourceData, m in
 		return diags
 	}
 
+	if err := instanceGroupsUpdate(ctx, d, m, "job_templates", id); err != nil {
+		return buildDiagUpdateFail("job template instance groups", id, err)
+	}
+	if err := labelsUpdate(ctx, d, m, "job_templates", id); err != nil {
+		return buildDiagUpdateFail("job template labels", id, err)
+	}
+	if d.HasChange("survey") {
+		if err := surveySpecUpdate(ctx, d, m, "job_templates", id); err != nil {
+			return buildDiagUpdateFail("job template survey", id, err)
+		}
+	}
 	return resourceJobTemplateRead(ctx, d, m)
 }
 
@@ -567,6 +639,15 @@ func resourceJobTemplateRead(ctx context.Context, d *schema.ResourceData, m inte
 
 	}
 	d = setJobTemplateResourceData(d, res)
+	if err := setInstanceGroupsResourceData(ctx, d, m, "job_templates", id); err != nil {
+		return buildDiagNotFoundFail("job template instance groups", id, err)
+	}
+	if err := setLabelsResourceData(ctx, d, m, "job_templates", id); err != nil {
+		return buildDiagNotFoundFail("job template labels", id, err)
+	}
+	if err := setSurveyResourceData(ctx, d, m, "job_templates", id); err != nil {
+		return buildDiagNotFoundFail("job template survey", id, err)
+	}
 	return nil
 }
 
@@ -574,7 +655,7 @@ func setJobTemplateResourceData(d *schema.ResourceData, r *awx.JobTemplate) *sch
 	d.Set("name", r.Name)
 	d.Set("description", r.Description)
 	d.Set("job_type", r.JobType)
-	d.Set("inventory_id", r.Inventory)
+	d.Set("inventory_id", strconv.Itoa(r.Inventory))
 	d.Set("project_id", r.Project)
 	d.Set("playbook", r.Playbook)
 	d.Set("scm_branch", r.SCMBranch)
@@ -606,7 +687,7 @@ func setJobTemplateResourceData(d *schema.ResourceData, r *awx.JobTemplate) *sch
 	d.Set("ask_forks_on_launch", r.AskForksOnLaunch)
 	d.Set("ask_job_slice_count_on_launch", r.AskJobSliceCountOnLaunch)
 	d.Set("ask_timeout_on_launch", r.AskTimeoutOnLaunch)
-	d.Set("ask_inventory_groups_on_launch", r.AskInstanceGroupsOnLaunch)
+	d.Set("ask_instance_groups_on_launch", r.AskInstanceGroupsOnLaunch)
 	d.Set("survey_enabled", r.SurveyEnabled)
 	d.Set("become_enabled", r.BecomeEnabled)
 	d.Set("diff_mode", r.DiffMode)
@@ -5,12 +5,23 @@
 
 ```hcl
 
-	resource "awx_job_template_credentials" "baseconfig" {
+	resource "awx_job_template_credential" "baseconfig" {
 	  job_template_id = awx_job_template.baseconfig.id
 	  credential_id   = awx_credential_machine.pi_connection.id
 	}
 
 ```
+
+# Import
+
+Job template credentials can be imported using the job template and the
+credential separated by a colon, each given by its ID or its name path
+`<organization>/<name>`.
+
+```sh
+terraform import awx_job_template_credential.baseconfig 12:34
+terraform import awx_job_template_credential.baseconfig Default/baseconfig:Default/pi-connection
+```
 */
 package awx
 
@@ -43,6 +54,9 @@ func resourceJobTemplateCredentials() *schema.Resource {
 				ForceNew: true,
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			State: resourceJobTemplateCredentialsImport,
+		},
 	}
 }
 
@@ -56,7 +70,7 @@ func resourceJobTemplateCredentialsCreate(ctx context.Context, d *schema.Resourc
 		return buildDiagNotFoundFail("job template", jobTemplateID, err)
 	}
 
-	result, err := awxService.AssociateCredentials(jobTemplateID, map[string]interface{}{
+	_, err = awxService.AssociateCredentials(jobTemplateID, map[string]interface{}{
 		"id": d.Get("credential_id").(int),
 	}, map[string]string{})
 
@@ -64,15 +78,47 @@ func resourceJobTemplateCredentialsCreate(ctx context.Context, d *schema.Resourc
 		return buildDiagnosticsMessage("Create: JobTemplate not AssociateCredentials", "Fail to add credentials with Id %v, for Template ID %v, got error: %s", d.Get("credential_id").(int), jobTemplateID, err.Error())
 	}
 
-	d.SetId(strconv.Itoa(result.ID))
+	d.SetId(fmt.Sprintf("%d:%d", jobTemplateID, d.Get("credential_id").(int)))
 	return diags
 }
 
 func resourceJobTemplateCredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+	jobTemplateID := d.Get("job_template_id").(int)
+	credentialID := d.Get("credential_id").(int)
+
+	credentials, err := getAPIClient(m).list(ctx, fmt.Sprintf("/api/v2/job_templates/%d/credentials/", jobTemplateID), map[string]string{
+		"id": strconv.Itoa(credentialID),
+	})
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return buildDiagNotFoundFail("job template credentials", jobTemplateID, err)
+	}
+	if len(credentials) == 0 {
+		d.SetId("")
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d", jobTemplateID, credentialID))
 	return diags
 }
 
+func resourceJobTemplateCredentialsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids, err := splitImportID(m, d.Id(),
+		importIDPart{"<job_template>", &namePathJobTemplate},
+		importIDPart{"<credential>", &namePathCredential},
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.Set("job_template_id", ids[0])
+	d.Set("credential_id", ids[1])
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceJobTemplateCredentialsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
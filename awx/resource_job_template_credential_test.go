@@ -0,0 +1,66 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceJobTemplateCredential(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testJobTemplateConfig("hello_world.yml") + `
+resource "awx_credential" "test" {
+  name               = "test-credential"
+  organization_id    = awx_organization.test.id
+  credential_type_id = 1
+  inputs             = jsonencode({ username = "alice" })
+}
+
+resource "awx_job_template_credential" "test" {
+  job_template_id = awx_job_template.test.id
+  credential_id   = awx_credential.test.id
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckJobTemplateCredentialID("awx_job_template_credential.test"),
+					fake.checkRelated("awx_job_template.test", "job_templates", "credentials", "awx_credential.test"),
+				),
+			},
+			{
+				ResourceName:      "awx_job_template_credential.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "awx_job_template_credential.test",
+				ImportState:   true,
+				ImportStateId: "not-an-id",
+				ExpectError:   regexp.MustCompile(`expected <job_template>:<credential>`),
+			},
+			{
+				ResourceName:      "awx_job_template_credential.test",
+				ImportState:       true,
+				ImportStateId:     "test-org/test-job-template:test-org/test-credential",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckJobTemplateCredentialID(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs := s.RootModule().Resources[name]
+		want := fmt.Sprintf("%s:%s", rs.Primary.Attributes["job_template_id"], rs.Primary.Attributes["credential_id"])
+		if rs.Primary.ID != want {
+			return fmt.Errorf("expected ID %s, got %s", want, rs.Primary.ID)
+		}
+		return nil
+	}
+}
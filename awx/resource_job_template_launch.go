@@ -28,9 +28,18 @@ resource "awx_job_template_launch" "postinstall" {
   limit                  = "sample-hostname"
   credential_ids         = [3]
   monitor_for_completion = true
+  cancel_on_destroy      = true
+  triggers = {
+    playbook = awx_job_template.baseconfig.playbook
+  }
 }
 ```
 
+Changing a launch parameter or a value of triggers launches the job again, so
+a changed configuration can re-run a provisioning playbook. With cancel_on_destroy the
+job is canceled when the resource is destroyed or replaced, if it is still
+running.
+
 */
 
 package awx
@@ -40,7 +49,6 @@ import (
 	"fmt"
 	"log"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -53,6 +61,7 @@ func resourceJobTemplateLaunch() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceJobTemplateLaunchCreate,
 		ReadContext:   resourceJobRead,
+		UpdateContext: resourceJobTemplateLaunchUpdate,
 		DeleteContext: resourceJobDelete,
 
 		Schema: map[string]*schema.Schema{
@@ -69,70 +78,94 @@ func resourceJobTemplateLaunch() *schema.Resource {
 				ForceNew:    true,
 				Description: "If true monitor job for successful completion",
 			},
+			"cancel_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true cancel the job on destroy if it is still running",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values which launch the job again when changed",
+			},
 			"job_type": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "Job type, one of run, check or scan",
 			},
 			"playbook": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "Playbook file name",
 			},
 			"forks": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "Forks",
 			},
 			"limit": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "Limit",
 			},
 			"verbosity": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "One of 0,1,2,3,4,5",
 			},
 			"extra_vars": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "Extra variables",
 			},
 			"job_tags": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "Job tags",
 			},
 			"skip_tags": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "Skip tags",
 			},
 			"timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "Timeout",
 			},
 			"scm_revision": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "SCM revision",
 			},
 			"diff_mode": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "Diff mode",
 			},
 			"credential_ids": {
@@ -140,12 +173,14 @@ func resourceJobTemplateLaunch() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeInt},
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "A list of credential IDs",
 			},
 			"execution_environment_id": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 				Description: "Execution environment ID",
 			},
 		},
@@ -241,6 +276,12 @@ func resourceJobTemplateLaunchCreate(ctx context.Context, d *schema.ResourceData
 	return resourceJobRead(ctx, d, m)
 }
 
+// resourceJobTemplateLaunchUpdate only handles changes of cancel_on_destroy,
+// any other change launches the job again.
+func resourceJobTemplateLaunchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceJobRead(ctx, d, m)
+}
+
 func resourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
@@ -248,16 +289,11 @@ func resourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{})
 	jobID, diags := convertStateIDToNummeric("Read Job", d)
 	job, err := awxService.GetJob(jobID, map[string]string{})
 	if err != nil {
-		time.Sleep(3 * time.Second)
-		retryJob, retryErr := awxService.GetJob(jobID, map[string]string{})
-		if retryErr != nil {
-			if check := strings.Contains(retryErr.Error(), "404 Not Found"); check {
-				d.SetId("")
-				return diags
-			}
-			return buildDiagNotFoundFail("job", jobID, retryErr)
+		if isNotFound(err) {
+			d.SetId("")
+			return diags
 		}
-		job = retryJob
+		return buildDiagNotFoundFail("job", jobID, err)
 	}
 	d = setJobResourceData(d, job)
 	return diags
@@ -274,11 +310,51 @@ func resourceJobDelete(ctx context.Context, d *schema.ResourceData, m interface{
 		d.SetId("")
 		return diags
 	}
-	// Should provide user option and if desired actually delete it
+	if d.Get("cancel_on_destroy").(bool) {
+		if err := cancelJob(ctx, m, jobID, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return buildDiagDeleteFail("job", fmt.Sprintf("unable to cancel job %d, got %s", jobID, err.Error()))
+		}
+	}
 	d.SetId("")
 	return diags
 }
 
+// cancelJob cancels a job if it is still running and waits for it to finish.
+func cancelJob(ctx context.Context, m interface{}, id int, timeout time.Duration) error {
+	path := fmt.Sprintf("/api/v2/jobs/%d/cancel/", id)
+	var cancel struct {
+		CanCancel bool `json:"can_cancel"`
+	}
+	if err := getAPIClient(m).get(ctx, path, nil, &cancel); err != nil {
+		return err
+	}
+	if !cancel.CanCancel {
+		return nil
+	}
+	log.Printf("Canceling job %d", id)
+	if err := getAPIClient(m).post(ctx, path, nil, nil); err != nil {
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{awx.JobStatusNew, awx.JobStatusPending, awx.JobStatusWaiting, awx.JobStatusRunning},
+		Target:  []string{awx.JobStatusSuccessful, awx.JobStatusCanceled, awx.JobStatusError, awx.JobStatusFailed},
+		Refresh: func() (interface{}, string, error) {
+			var job struct {
+				Status string `json:"status"`
+			}
+			if err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/jobs/%d/", id), nil, &job); err != nil {
+				return nil, "", err
+			}
+			return job, job.Status, nil
+		},
+		MinTimeout: 5 * time.Second,
+		Timeout:    timeout,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
 func setJobResourceData(d *schema.ResourceData, r *awx.Job) *schema.ResourceData {
 	d.Set("name", r.Name)
 	d.Set("description", r.Description)
@@ -311,12 +387,7 @@ func isJobRefreshFunc(client *awx.AWX, id string) resource.StateRefreshFunc {
 		jobID, _ = strconv.Atoi(id)
 		job, err := client.JobService.GetJob(jobID, map[string]string{})
 		if err != nil {
-			time.Sleep(5 * time.Second)
-			retryJob, retryErr := client.JobService.GetJob(jobID, map[string]string{})
-			if retryErr != nil {
-				return nil, "", retryErr
-			}
-			job = retryJob
+			return nil, "", err
 		}
 		if job.Status == awx.JobStatusSuccessful {
 			return job, job.Status, nil
@@ -0,0 +1,116 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testJobTemplateLaunchConfig(cancelOnDestroy bool, revision, limit string) string {
+	return testJobTemplateConfig("site.yml") + fmt.Sprintf(`
+resource "awx_job_template_launch" "test" {
+  job_template_id   = awx_job_template.test.id
+  limit             = %q
+  cancel_on_destroy = %t
+  triggers = {
+    revision = %q
+  }
+}
+`, limit, cancelOnDestroy, revision)
+}
+
+// runJobs marks every job known to the fake server as running.
+func (f *fakeAWX) runJobs() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, job := range f.collections["jobs"] {
+		job["status"] = "running"
+	}
+}
+
+// checkJobStatus verifies the status of the job with the ID stored in id.
+func (f *fakeAWX) checkJobStatus(id *string, status string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		job, ok := f.collections["jobs"][int(fakeNumber(*id))]
+		if !ok {
+			return fmt.Errorf("job %s not found", *id)
+		}
+		if got := fakeString(job["status"]); got != status {
+			return fmt.Errorf("expected job %s to be %s, got %s", *id, status, got)
+		}
+		return nil
+	}
+}
+
+func storeJobID(id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["awx_job_template_launch.test"]
+		if !ok {
+			return fmt.Errorf("resource awx_job_template_launch.test not found in state")
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func TestResourceJobTemplateLaunch(t *testing.T) {
+	fake := newFakeAWX(t)
+	var first, second string
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy: func(s *terraform.State) error {
+			// Without cancel_on_destroy the job is left running.
+			return fake.checkJobStatus(&second, "running")(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testJobTemplateLaunchConfig(true, "1", "web")),
+				Check: resource.ComposeTestCheckFunc(
+					storeJobID(&first),
+					fake.checkField("awx_job_template_launch.test", "jobs", "limit", "web"),
+				),
+			},
+			{
+				// Changed triggers launch the job again, canceling the
+				// running one.
+				PreConfig: fake.runJobs,
+				Config:    fake.config(testJobTemplateLaunchConfig(true, "2", "web")),
+				Check: resource.ComposeTestCheckFunc(
+					storeJobID(&second),
+					fake.checkJobStatus(&first, "canceled"),
+					func(s *terraform.State) error {
+						if first == second {
+							return fmt.Errorf("job %s was not launched again", first)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: fake.runJobs,
+				Config:    fake.config(testJobTemplateLaunchConfig(false, "2", "web")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("awx_job_template_launch.test", "id", &second),
+					resource.TestCheckResourceAttr("awx_job_template_launch.test", "cancel_on_destroy", "false"),
+				),
+			},
+			{
+				// Changed launch parameters launch the job again.
+				Config: fake.config(testJobTemplateLaunchConfig(false, "2", "db")),
+				Check: resource.ComposeTestCheckFunc(
+					fake.checkField("awx_job_template_launch.test", "jobs", "limit", "db"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["awx_job_template_launch.test"].Primary.ID == second {
+							return fmt.Errorf("job %s was not launched again", second)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,270 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testJobTemplateConfig(playbook string) string {
+	return fmt.Sprintf(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_inventory" "test" {
+  name            = "test-inventory"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_project" "test" {
+  name            = "test-project"
+  scm_type        = "git"
+  scm_url         = "https://github.com/ansible/ansible-tower-samples"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_job_template" "test" {
+  name         = "test-job-template"
+  job_type     = "run"
+  inventory_id = awx_inventory.test.id
+  project_id   = awx_project.test.id
+  playbook     = %q
+}
+`, playbook)
+}
+
+func TestResourceJobTemplate(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_job_template", "job_templates"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testJobTemplateConfig("hello_world.yml")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_job_template.test", "playbook", "hello_world.yml"),
+					resource.TestCheckResourceAttrPair("awx_job_template.test", "project_id", "awx_project.test", "id"),
+					fake.checkField("awx_job_template.test", "job_templates", "playbook", "hello_world.yml"),
+				),
+			},
+			{
+				Config: fake.config(testJobTemplateConfig("site.yml")),
+				Check:  fake.checkField("awx_job_template.test", "job_templates", "playbook", "site.yml"),
+			},
+			{
+				ResourceName:      "awx_job_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_job_template.test",
+				ImportState:       true,
+				ImportStateId:     "test-org/test-job-template",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testJobTemplateSurveyConfig(survey string) string {
+	return testJobTemplateConfig("hello_world.yml") + fmt.Sprintf(`
+resource "awx_job_template" "survey" {
+  name           = "test-survey"
+  job_type       = "run"
+  inventory_id   = awx_inventory.test.id
+  project_id     = awx_project.test.id
+  playbook       = "hello_world.yml"
+  survey_enabled = true
+%s
+}
+`, survey)
+}
+
+var testJobTemplateSurveyNumericConfig = testJobTemplateSurveyConfig(`
+  survey {
+    name = "deploy"
+
+    question {
+      question_name = "Hosts"
+      variable      = "hosts"
+      type          = "text"
+    }
+
+    question {
+      question_name = "Ratio"
+      variable      = "ratio"
+      type          = "float"
+      default       = "1.50"
+    }
+
+    question {
+      question_name    = "Token"
+      variable         = "token"
+      type             = "password"
+      password_default = "s3cret"
+    }
+
+    question {
+      question_name = "Retries"
+      variable      = "retries"
+      type          = "integer"
+      min           = 0
+    }
+  }
+`)
+
+func TestResourceJobTemplateSurvey(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_job_template", "job_templates"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testJobTemplateSurveyConfig(`
+  survey {
+    question {
+      question_name = "Environment"
+      variable      = "environment"
+      type          = "multiplechoice"
+      choices       = ["staging", "production"]
+      default       = "testing"
+    }
+  }
+`)),
+				ExpectError: regexp.MustCompile(`default "testing" is not one of the choices`),
+			},
+			{
+				Config: fake.config(testJobTemplateSurveyConfig(`
+  survey {
+    name = "deploy"
+
+    question {
+      question_name = "Environment"
+      variable      = "environment"
+      type          = "multiplechoice"
+      choices       = ["staging", "production"]
+      default       = "staging"
+      required      = true
+    }
+
+    question {
+      question_name = "Parallel hosts"
+      variable      = "serial"
+      type          = "integer"
+      min           = 1
+      max           = 50
+      default       = "10"
+    }
+  }
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_job_template.survey", "survey.0.question.#", "2"),
+					resource.TestCheckResourceAttr("awx_job_template.survey", "survey.0.question.0.choices.#", "2"),
+					resource.TestCheckResourceAttr("awx_job_template.survey", "survey.0.question.1.default", "10"),
+					fake.checkSurvey("awx_job_template.survey", "job_templates", "environment", "serial"),
+				),
+			},
+			{
+				ResourceName:      "awx_job_template.survey",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fake.config(testJobTemplateSurveyConfig(`
+  survey {
+    name = "deploy"
+
+    question {
+      question_name = "Hosts"
+      variable      = "hosts"
+      type          = "text"
+      max           = 200
+    }
+  }
+`)),
+				Check: fake.checkSurvey("awx_job_template.survey", "job_templates", "hosts"),
+			},
+			{
+				// max is reset, min = 0 is sent, numeric defaults are
+				// compared as numbers and encrypted password defaults keep
+				// the configured value.
+				Config: fake.config(testJobTemplateSurveyNumericConfig),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_job_template.survey", "survey.0.question.0.max", ""),
+					resource.TestCheckResourceAttr("awx_job_template.survey", "survey.0.question.1.default", "1.50"),
+					resource.TestCheckResourceAttr("awx_job_template.survey", "survey.0.question.3.min", "0"),
+					resource.TestCheckResourceAttr("awx_job_template.survey", "survey.0.question.3.max", ""),
+					fake.checkSurvey("awx_job_template.survey", "job_templates", "hosts", "ratio", "token", "retries"),
+					fake.checkSurveyQuestion("awx_job_template.survey", "job_templates", "hosts", "max", nil),
+					fake.checkSurveyQuestion("awx_job_template.survey", "job_templates", "retries", "min", float64(0)),
+					fake.checkSurveyQuestion("awx_job_template.survey", "job_templates", "retries", "max", nil),
+				),
+			},
+			{
+				PreConfig: func() {
+					fake.mu.Lock()
+					defer fake.mu.Unlock()
+					for _, survey := range fake.surveys {
+						for _, q := range survey["spec"].([]interface{}) {
+							if question := q.(map[string]interface{}); question["type"] == "password" {
+								question["default"] = awxEncrypted
+							}
+						}
+					}
+				},
+				Config:   fake.config(testJobTemplateSurveyNumericConfig),
+				PlanOnly: true,
+			},
+			{
+				Config: fake.config(testJobTemplateSurveyConfig("")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_job_template.survey", "survey.#", "0"),
+					fake.checkSurvey("awx_job_template.survey", "job_templates"),
+				),
+			},
+		},
+	})
+}
+
+func testJobTemplateExecutionEnvironmentConfig(executionEnvironment string) string {
+	return testJobTemplateConfig("hello_world.yml") + fmt.Sprintf(`
+resource "awx_execution_environment" "test" {
+  name  = "test-ee"
+  image = "quay.io/ansible/awx-ee:latest"
+}
+
+resource "awx_job_template" "ee" {
+  name         = "test-ee"
+  job_type     = "run"
+  inventory_id = awx_inventory.test.id
+  project_id   = awx_project.test.id
+  playbook     = "hello_world.yml"
+%s
+}
+`, executionEnvironment)
+}
+
+func TestResourceJobTemplateExecutionEnvironment(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_job_template", "job_templates"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testJobTemplateExecutionEnvironmentConfig("  execution_environment_id = awx_execution_environment.test.id")),
+				Check:  resource.TestCheckResourceAttrPair("awx_job_template.ee", "execution_environment_id", "awx_execution_environment.test", "id"),
+			},
+			{
+				// Removing the execution environment clears it in AWX.
+				Config: fake.config(testJobTemplateExecutionEnvironmentConfig("")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_job_template.ee", "execution_environment_id", "0"),
+					fake.checkField("awx_job_template.ee", "job_templates", "execution_environment", nil),
+				),
+			},
+		},
+	})
+}
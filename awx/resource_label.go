@@ -0,0 +1,204 @@
+/*
+Labels group templates and the jobs launched from them.
+
+AWX does not allow to delete labels, they are removed by AWX once they are
+not used by any template or job anymore. Destroying an awx_label only removes
+it from the Terraform state.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_label" "nightly" {
+	  name            = "nightly"
+	  organization_id = awx_organization.default.id
+	}
+
+	resource "awx_job_template" "backup" {
+	  name         = "backup"
+	  job_type     = "run"
+	  inventory_id = awx_inventory.default.id
+	  project_id   = awx_project.default.id
+	  playbook     = "backup.yml"
+	  labels       = [awx_label.nightly.id]
+	}
+
+```
+
+# Import
+
+Labels can be imported using the label ID or the name path
+`<organization>/<name>`.
+
+```sh
+terraform import awx_label.nightly 9
+terraform import awx_label.nightly Default/nightly
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// awxLabel is the AWX label object.
+type awxLabel struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Organization int    `json:"organization"`
+}
+
+// labelsSchema is the set of labels attached to a job template or workflow
+// job template. When it is not configured the labels attached in AWX are
+// left alone.
+var labelsSchema = &schema.Schema{
+	Type:        schema.TypeSet,
+	Elem:        &schema.Schema{Type: schema.TypeInt},
+	Optional:    true,
+	Computed:    true,
+	Description: "Numeric IDs of the labels attached to this template, the labels are not managed when unset",
+}
+
+// customizeDiffLabels detaches all labels of a template configured with
+// labels = [].
+var customizeDiffLabels = customizeDiffConfiguredEmpty("labels", schema.NewSet(schema.HashInt, nil))
+
+func resourceLabel() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLabelCreate,
+		ReadContext:   resourceLabelRead,
+		UpdateContext: resourceLabelUpdate,
+		DeleteContext: resourceLabelDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of this label",
+			},
+			"organization_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the organization the label belongs to",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathLabel),
+		},
+	}
+}
+
+func resourceLabelCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	payload := map[string]interface{}{
+		"name":         d.Get("name").(string),
+		"organization": d.Get("organization_id").(int),
+	}
+
+	var label awxLabel
+	if err := getAPIClient(m).post(ctx, "/api/v2/labels/", payload, &label); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: Label not created",
+			"Label with name %s in the organization ID %v not created, %s",
+			d.Get("name").(string), d.Get("organization_id").(int), err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(label.ID))
+	return resourceLabelRead(ctx, d, m)
+}
+
+func resourceLabelUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Update Label", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	payload := map[string]interface{}{
+		"name": d.Get("name").(string),
+	}
+	if err := getAPIClient(m).patch(ctx, fmt.Sprintf("/api/v2/labels/%d/", id), payload, nil); err != nil {
+		return buildDiagUpdateFail("label", id, err)
+	}
+	return resourceLabelRead(ctx, d, m)
+}
+
+func resourceLabelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read Label", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	var label awxLabel
+	err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/labels/%d/", id), nil, &label)
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return buildDiagNotFoundFail("label", id, err)
+	}
+
+	d.Set("name", label.Name)
+	d.Set("organization_id", label.Organization)
+	return diags
+}
+
+// resourceLabelDelete only forgets the label, AWX removes labels which are
+// not in use by itself and rejects deleting them through the API.
+func resourceLabelDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
+
+// labelsUpdate associates added and disassociates removed labels of a
+// template through its /labels/ endpoint.
+func labelsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}, collection string, id int) error {
+	if !d.HasChange("labels") {
+		return nil
+	}
+	o, n := d.GetChange("labels")
+	oldSet, newSet := o.(*schema.Set), n.(*schema.Set)
+
+	path := fmt.Sprintf("/api/v2/%s/%d/labels/", collection, id)
+	for _, v := range oldSet.Difference(newSet).List() {
+		payload := map[string]interface{}{
+			"id":           v.(int),
+			"disassociate": true, // presence of key triggers removal
+		}
+		if err := getAPIClient(m).post(ctx, path, payload, nil); err != nil {
+			return err
+		}
+	}
+	for _, v := range newSet.Difference(oldSet).List() {
+		payload := map[string]interface{}{
+			"id": v.(int),
+		}
+		if err := getAPIClient(m).post(ctx, path, payload, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setLabelsResourceData reads the labels attached to a template.
+func setLabelsResourceData(ctx context.Context, d *schema.ResourceData, m interface{}, collection string, id int) error {
+	labels, err := getAPIClient(m).list(ctx, fmt.Sprintf("/api/v2/%s/%d/labels/", collection, id), nil)
+	if err != nil {
+		return err
+	}
+
+	var labelIDs []interface{}
+	for _, l := range labels {
+		if id, ok := l["id"].(float64); ok {
+			labelIDs = append(labelIDs, int(id))
+		}
+	}
+	return d.Set("labels", schema.NewSet(schema.HashInt, labelIDs))
+}
@@ -0,0 +1,94 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testLabelConfig(labels string) string {
+	return testJobTemplateConfig("hello_world.yml") + fmt.Sprintf(`
+resource "awx_label" "nightly" {
+  name            = "nightly"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_label" "backup" {
+  name            = "backup"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_job_template" "labeled" {
+  name         = "test-labeled"
+  job_type     = "run"
+  inventory_id = awx_inventory.test.id
+  project_id   = awx_project.test.id
+  playbook     = "hello_world.yml"
+  %s
+}
+`, labels)
+}
+
+func TestResourceLabel(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testLabelConfig("labels = [awx_label.nightly.id]")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_label.nightly", "name", "nightly"),
+					resource.TestCheckResourceAttr("awx_job_template.labeled", "labels.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("awx_job_template.labeled", "labels.*", "awx_label.nightly", "id"),
+					fake.checkRelated("awx_job_template.labeled", "job_templates", "labels", "awx_label.nightly"),
+				),
+			},
+			{
+				Config: fake.config(testLabelConfig("labels = [awx_label.backup.id]")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_job_template.labeled", "labels.#", "1"),
+					fake.checkRelated("awx_job_template.labeled", "job_templates", "labels", "awx_label.backup"),
+					fake.checkNotRelated("awx_job_template.labeled", "job_templates", "labels", "awx_label.nightly"),
+				),
+			},
+			{
+				// Without labels the labels attached in AWX are kept.
+				Config: fake.config(testLabelConfig("")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_job_template.labeled", "labels.#", "1"),
+					fake.checkRelated("awx_job_template.labeled", "job_templates", "labels", "awx_label.backup"),
+					fake.associate("awx_job_template.labeled", "job_templates", "labels", "awx_label.nightly"),
+				),
+			},
+			{
+				Config:   fake.config(testLabelConfig("")),
+				PlanOnly: true,
+			},
+			{
+				Config: fake.config(testLabelConfig("labels = []")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_job_template.labeled", "labels.#", "0"),
+					fake.checkNotRelated("awx_job_template.labeled", "job_templates", "labels", "awx_label.backup"),
+					fake.checkNotRelated("awx_job_template.labeled", "job_templates", "labels", "awx_label.nightly"),
+				),
+			},
+			{
+				ResourceName:      "awx_label.nightly",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_label.backup",
+				ImportState:       true,
+				ImportStateId:     "test-org/backup",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_job_template.labeled",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,343 @@
+/*
+*TBD*
+
+# Example Usage
+
+```hcl
+
+	resource "awx_notification_template" "ops_slack" {
+	  name            = "ops-slack"
+	  organization_id = awx_organization.default.id
+
+	  slack {
+	    token     = var.slack_token
+	    channels  = ["#ops"]
+	    hex_color = "#ff0000"
+	  }
+	}
+
+	resource "awx_notification_template" "ops_mail" {
+	  name            = "ops-mail"
+	  organization_id = awx_organization.default.id
+
+	  email {
+	    host       = "smtp.example.com"
+	    port       = 587
+	    use_tls    = true
+	    username   = "awx"
+	    password   = var.smtp_password
+	    sender     = "awx@example.com"
+	    recipients = ["ops@example.com"]
+	  }
+	}
+
+```
+
+# Import
+
+Notification templates can be imported using the notification template ID or
+the name path `<organization>/<name>`. Secrets like tokens and passwords are
+not returned by AWX and have to be set in the configuration.
+
+```sh
+terraform import awx_notification_template.ops_slack 4
+terraform import awx_notification_template.ops_slack Default/ops-slack
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// awxEncrypted is returned by AWX in place of secret values.
+const awxEncrypted = "$encrypted$"
+
+// awxNotificationTemplate is the subset of the AWX notification template
+// object managed by the provider.
+type awxNotificationTemplate struct {
+	ID                        int                    `json:"id"`
+	Name                      string                 `json:"name"`
+	Description               string                 `json:"description"`
+	Organization              int                    `json:"organization"`
+	NotificationType          string                 `json:"notification_type"`
+	NotificationConfiguration map[string]interface{} `json:"notification_configuration"`
+}
+
+// notificationField is one key of the notification_configuration of a
+// notification type. Fields are named like the keys of the AWX API.
+type notificationField struct {
+	name        string
+	kind        schema.ValueType
+	required    bool
+	sensitive   bool
+	def         interface{}
+	description string
+}
+
+// notificationTypes are the supported notification types, every type is
+// configured by a block of the same name.
+var notificationTypes = []string{"email", "irc", "mattermost", "pagerduty", "slack", "webhook"}
+
+var notificationFields = map[string][]notificationField{
+	"email": {
+		{name: "host", kind: schema.TypeString, required: true, description: "SMTP server"},
+		{name: "port", kind: schema.TypeInt, required: true, description: "SMTP port"},
+		{name: "sender", kind: schema.TypeString, required: true, description: "Sender email address"},
+		{name: "recipients", kind: schema.TypeList, required: true, description: "Recipient email addresses"},
+		{name: "username", kind: schema.TypeString, description: "SMTP username"},
+		{name: "password", kind: schema.TypeString, sensitive: true, description: "SMTP password"},
+		{name: "use_tls", kind: schema.TypeBool, description: "Use STARTTLS"},
+		{name: "use_ssl", kind: schema.TypeBool, description: "Use SSL"},
+		{name: "timeout", kind: schema.TypeInt, def: 30, description: "Timeout in seconds"},
+	},
+	"irc": {
+		{name: "server", kind: schema.TypeString, required: true, description: "IRC server address"},
+		{name: "port", kind: schema.TypeInt, required: true, description: "IRC server port"},
+		{name: "nickname", kind: schema.TypeString, required: true, description: "IRC nick"},
+		{name: "targets", kind: schema.TypeList, required: true, description: "Channels or users to notify"},
+		{name: "password", kind: schema.TypeString, sensitive: true, description: "IRC server password"},
+		{name: "use_ssl", kind: schema.TypeBool, description: "Use SSL"},
+	},
+	"mattermost": {
+		{name: "mattermost_url", kind: schema.TypeString, required: true, description: "Incoming webhook URL"},
+		{name: "mattermost_username", kind: schema.TypeString, description: "Username of the notifications"},
+		{name: "mattermost_channel", kind: schema.TypeString, description: "Channel to notify"},
+		{name: "mattermost_icon_url", kind: schema.TypeString, description: "Icon URL of the notifications"},
+		{name: "mattermost_no_verify_ssl", kind: schema.TypeBool, description: "Skip the verification of the SSL certificate"},
+	},
+	"pagerduty": {
+		{name: "subdomain", kind: schema.TypeString, required: true, description: "Pagerduty subdomain"},
+		{name: "client_name", kind: schema.TypeString, required: true, description: "Client identifier"},
+		{name: "token", kind: schema.TypeString, required: true, sensitive: true, description: "API token"},
+		{name: "service_key", kind: schema.TypeString, required: true, sensitive: true, description: "API service/integration key"},
+	},
+	"slack": {
+		{name: "token", kind: schema.TypeString, required: true, sensitive: true, description: "Slack bot token"},
+		{name: "channels", kind: schema.TypeList, required: true, description: "Channels to notify"},
+		{name: "hex_color", kind: schema.TypeString, description: "Notification color, e.g. #ff0000"},
+	},
+	"webhook": {
+		{name: "url", kind: schema.TypeString, required: true, description: "Target URL"},
+		{name: "http_method", kind: schema.TypeString, def: "POST", description: "HTTP method, POST or PUT"},
+		{name: "headers", kind: schema.TypeMap, description: "HTTP headers"},
+		{name: "username", kind: schema.TypeString, description: "Basic auth username"},
+		{name: "password", kind: schema.TypeString, sensitive: true, description: "Basic auth password"},
+		{name: "disable_ssl_verification", kind: schema.TypeBool, description: "Skip the verification of the SSL certificate"},
+	},
+}
+
+func resourceNotificationTemplate() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of this notification template",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Optional description of this notification template.",
+		},
+		"organization_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			Description: "Numeric ID of the organization owning the notification template",
+		},
+		"notification_type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Notification type, derived from the configuration block",
+		},
+	}
+	for _, t := range notificationTypes {
+		s[t] = &schema.Schema{
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: notificationTypes,
+			Elem:         notificationConfigurationResource(notificationFields[t]),
+			Description:  fmt.Sprintf("Configuration of %s notifications", t),
+		}
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceNotificationTemplateCreate,
+		ReadContext:   resourceNotificationTemplateRead,
+		UpdateContext: resourceNotificationTemplateUpdate,
+		DeleteContext: resourceNotificationTemplateDelete,
+
+		Schema: s,
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathNotificationTemplate),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+	}
+}
+
+func notificationConfigurationResource(fields []notificationField) *schema.Resource {
+	s := make(map[string]*schema.Schema, len(fields))
+	for _, f := range fields {
+		fs := &schema.Schema{
+			Type:        f.kind,
+			Required:    f.required,
+			Optional:    !f.required,
+			Sensitive:   f.sensitive,
+			Default:     f.def,
+			Description: f.description,
+		}
+		if f.kind == schema.TypeList || f.kind == schema.TypeMap {
+			fs.Elem = &schema.Schema{Type: schema.TypeString}
+		}
+		s[f.name] = fs
+	}
+	return &schema.Resource{Schema: s}
+}
+
+// notificationTemplateConfiguration returns the notification type and the
+// notification_configuration of the block set in the configuration.
+func notificationTemplateConfiguration(d *schema.ResourceData) (string, map[string]interface{}) {
+	for _, t := range notificationTypes {
+		blocks := d.Get(t).([]interface{})
+		if len(blocks) == 0 || blocks[0] == nil {
+			continue
+		}
+		block := blocks[0].(map[string]interface{})
+		config := make(map[string]interface{}, len(block))
+		for _, f := range notificationFields[t] {
+			config[f.name] = block[f.name]
+		}
+		return t, config
+	}
+	return "", map[string]interface{}{}
+}
+
+func notificationTemplatePayload(d *schema.ResourceData) map[string]interface{} {
+	notificationType, config := notificationTemplateConfiguration(d)
+	return map[string]interface{}{
+		"name":                       d.Get("name").(string),
+		"description":                d.Get("description").(string),
+		"organization":               d.Get("organization_id").(int),
+		"notification_type":          notificationType,
+		"notification_configuration": config,
+	}
+}
+
+func resourceNotificationTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var template awxNotificationTemplate
+	err := getAPIClient(m).post(ctx, "/api/v2/notification_templates/", notificationTemplatePayload(d), &template)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Create: NotificationTemplate not created",
+			"NotificationTemplate with name %s in the organization ID %v not created, %s",
+			d.Get("name").(string), d.Get("organization_id").(int), err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(template.ID))
+	return resourceNotificationTemplateRead(ctx, d, m)
+}
+
+func resourceNotificationTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Update NotificationTemplate", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := getAPIClient(m).patch(ctx, fmt.Sprintf("/api/v2/notification_templates/%d/", id), notificationTemplatePayload(d), nil)
+	if err != nil {
+		return buildDiagUpdateFail("notification template", id, err)
+	}
+	return resourceNotificationTemplateRead(ctx, d, m)
+}
+
+func resourceNotificationTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read NotificationTemplate", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	var template awxNotificationTemplate
+	err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/notification_templates/%d/", id), nil, &template)
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return buildDiagNotFoundFail("notification template", id, err)
+	}
+
+	d = setNotificationTemplateResourceData(d, &template)
+	return diags
+}
+
+func resourceNotificationTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Delete NotificationTemplate", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := getAPIClient(m).delete(ctx, fmt.Sprintf("/api/v2/notification_templates/%d/", id))
+	if err != nil && !isNotFound(err) {
+		return buildDiagDeleteFail("NotificationTemplate", fmt.Sprintf("NotificationTemplateID %v, got %s ", id, err.Error()))
+	}
+	d.SetId("")
+	return diags
+}
+
+// flattenNotificationConfiguration converts the notification_configuration
+// returned by AWX to a configuration block. AWX replaces secrets with
+// $encrypted$, these are taken from the current block instead.
+func flattenNotificationConfiguration(fields []notificationField, config, current map[string]interface{}) map[string]interface{} {
+	block := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		v, ok := config[f.name]
+		if !ok || v == nil {
+			continue
+		}
+		if f.sensitive && v == awxEncrypted {
+			v = current[f.name]
+		}
+		if n, ok := v.(float64); ok && f.kind == schema.TypeInt {
+			v = int(n)
+		}
+		block[f.name] = v
+	}
+	return block
+}
+
+func setNotificationTemplateResourceData(d *schema.ResourceData, r *awxNotificationTemplate) *schema.ResourceData {
+	d.Set("name", r.Name)
+	d.Set("description", r.Description)
+	d.Set("organization_id", r.Organization)
+	d.Set("notification_type", r.NotificationType)
+
+	for _, t := range notificationTypes {
+		if t != r.NotificationType {
+			d.Set(t, nil)
+			continue
+		}
+		current := map[string]interface{}{}
+		if blocks := d.Get(t).([]interface{}); len(blocks) > 0 && blocks[0] != nil {
+			current = blocks[0].(map[string]interface{})
+		}
+		d.Set(t, []interface{}{
+			flattenNotificationConfiguration(notificationFields[t], r.NotificationConfiguration, current),
+		})
+	}
+
+	d.SetId(strconv.Itoa(r.ID))
+	return d
+}
@@ -0,0 +1,243 @@
+/*
+*TBD*
+
+# Example Usage
+
+```hcl
+
+	resource "awx_notification_template_association" "deploy_failed" {
+	  notification_template_id = awx_notification_template.ops_slack.id
+	  job_template_id          = awx_job_template.deploy.id
+	  event                    = "error"
+	}
+
+	resource "awx_notification_template_association" "default_approval" {
+	  notification_template_id = awx_notification_template.ops_mail.id
+	  organization_id          = awx_organization.default.id
+	  event                    = "approval"
+	}
+
+```
+
+# Import
+
+Notification template associations can be imported using the type of the
+template or organization, the template or organization, the event and the
+notification template separated by colons. The template, organization and
+notification template are given by their ID or their name path, like
+`<organization>/<name>`.
+
+```sh
+terraform import awx_notification_template_association.deploy_failed job_template:12:error:4
+terraform import awx_notification_template_association.deploy_failed job_template:Default/deploy:error:Default/ops-slack
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// notificationParent is an object notification templates can be attached to.
+type notificationParent struct {
+	kind       string
+	collection string
+	approval   bool
+	path       namePath
+}
+
+var notificationParents = []notificationParent{
+	{kind: "job_template", collection: "job_templates", path: namePathJobTemplate},
+	{kind: "workflow_job_template", collection: "workflow_job_templates", approval: true, path: namePathWorkflowJobTemplate},
+	{kind: "project", collection: "projects", path: namePathProject},
+	{kind: "inventory_source", collection: "inventory_sources", path: namePathInventorySource},
+	{kind: "organization", collection: "organizations", approval: true, path: namePathOrganization},
+}
+
+var notificationEvents = []string{"started", "success", "error", "approval"}
+
+func resourceNotificationTemplateAssociation() *schema.Resource {
+	var parentKeys []string
+	for _, p := range notificationParents {
+		parentKeys = append(parentKeys, p.kind+"_id")
+	}
+
+	s := map[string]*schema.Schema{
+		"notification_template_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Numeric ID of the notification template",
+		},
+		"event": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice(notificationEvents, false),
+			Description:  "Event sending the notification, one of started, success, error or approval. Approval is only available for workflow job templates and organizations",
+		},
+	}
+	for _, p := range notificationParents {
+		s[p.kind+"_id"] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: parentKeys,
+			Description:  fmt.Sprintf("Numeric ID of the %s to attach the notification template to", strings.ReplaceAll(p.kind, "_", " ")),
+		}
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceNotificationTemplateAssociationCreate,
+		ReadContext:   resourceNotificationTemplateAssociationRead,
+		DeleteContext: resourceNotificationTemplateAssociationDelete,
+		CustomizeDiff: resourceNotificationTemplateAssociationCustomizeDiff,
+
+		Schema: s,
+		Importer: &schema.ResourceImporter{
+			State: resourceNotificationTemplateAssociationImport,
+		},
+	}
+}
+
+// resourceNotificationTemplateAssociationCustomizeDiff rejects approval
+// notifications for objects which have no approvals at plan time.
+func resourceNotificationTemplateAssociationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("event").(string) != "approval" {
+		return nil
+	}
+	for _, p := range notificationParents {
+		key := p.kind + "_id"
+		if !p.approval && (d.Get(key).(int) != 0 || !d.NewValueKnown(key)) {
+			return fmt.Errorf("approval notifications are only available for workflow job templates and organizations, not for %s_id", p.kind)
+		}
+	}
+	return nil
+}
+
+// notificationTemplateAssociationParent returns the object the
+// notification template is attached to and its ID.
+func notificationTemplateAssociationParent(d *schema.ResourceData) (notificationParent, int) {
+	for _, p := range notificationParents {
+		if id := d.Get(p.kind + "_id").(int); id != 0 {
+			return p, id
+		}
+	}
+	return notificationParent{}, 0
+}
+
+func notificationTemplateAssociationPath(p notificationParent, parentID int, event string) string {
+	if event == "approval" {
+		event = "approvals" // the only related endpoint named in plural
+	}
+	return fmt.Sprintf("/api/v2/%s/%d/notification_templates_%s/", p.collection, parentID, event)
+}
+
+func notificationTemplateAssociationID(p notificationParent, parentID int, event string, templateID int) string {
+	return fmt.Sprintf("%s:%d:%s:%d", p.kind, parentID, event, templateID)
+}
+
+func resourceNotificationTemplateAssociationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p, parentID := notificationTemplateAssociationParent(d)
+	event := d.Get("event").(string)
+	templateID := d.Get("notification_template_id").(int)
+
+	payload := map[string]interface{}{
+		"id": templateID,
+	}
+	if err := getAPIClient(m).post(ctx, notificationTemplateAssociationPath(p, parentID, event), payload, nil); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: NotificationTemplate not attached",
+			"Fail to attach notification template with ID %v to %s with ID %v for %s events, got %s",
+			templateID, p.kind, parentID, event, err.Error(),
+		)
+	}
+
+	d.SetId(notificationTemplateAssociationID(p, parentID, event, templateID))
+	return resourceNotificationTemplateAssociationRead(ctx, d, m)
+}
+
+func resourceNotificationTemplateAssociationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p, parentID := notificationTemplateAssociationParent(d)
+	event := d.Get("event").(string)
+	templateID := d.Get("notification_template_id").(int)
+
+	templates, err := getAPIClient(m).list(ctx, notificationTemplateAssociationPath(p, parentID, event), map[string]string{
+		"id": strconv.Itoa(templateID),
+	})
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return buildDiagNotFoundFail(fmt.Sprintf("%s notification templates", p.kind), parentID, err)
+	}
+	if len(templates) == 0 {
+		d.SetId("")
+		return diags
+	}
+
+	d.SetId(notificationTemplateAssociationID(p, parentID, event, templateID))
+	return diags
+}
+
+func resourceNotificationTemplateAssociationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p, parentID := notificationTemplateAssociationParent(d)
+	event := d.Get("event").(string)
+	templateID := d.Get("notification_template_id").(int)
+
+	payload := map[string]interface{}{
+		"id":           templateID,
+		"disassociate": true, // presence of key triggers removal
+	}
+	err := getAPIClient(m).post(ctx, notificationTemplateAssociationPath(p, parentID, event), payload, nil)
+	if err != nil && !isNotFound(err) {
+		return buildDiagDeleteFail(
+			"NotificationTemplate association",
+			fmt.Sprintf("NotificationTemplateID %v from %s %v for %s events, got %s ", templateID, p.kind, parentID, event, err.Error()),
+		)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceNotificationTemplateAssociationImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	const format = "<type>:<template>:<event>:<notification_template>"
+	values := strings.SplitN(d.Id(), ":", 4)
+	if len(values) != 4 {
+		return nil, fmt.Errorf("unexpected format of ID %q, expected %s", d.Id(), format)
+	}
+
+	kind, event := values[0], values[2]
+	var parent *notificationParent
+	for i, p := range notificationParents {
+		if p.kind == kind {
+			parent = &notificationParents[i]
+		}
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("unknown type %q in ID %q, expected job_template, workflow_job_template, project, inventory_source or organization", kind, d.Id())
+	}
+	ids, err := splitImportID(m, values[1]+":"+values[3],
+		importIDPart{"<template>", &parent.path},
+		importIDPart{"<notification_template>", &namePathNotificationTemplate},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set(kind+"_id", ids[0])
+	d.Set("event", event)
+	d.Set("notification_template_id", ids[1])
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,67 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testNotificationTemplateAssociationConfig(event string) string {
+	return testJobTemplateConfig("hello_world.yml") + fmt.Sprintf(`
+resource "awx_notification_template" "test" {
+  name            = "ops"
+  organization_id = awx_organization.test.id
+
+  slack {
+    token    = "xoxb-secret"
+    channels = ["#ops"]
+  }
+}
+
+resource "awx_notification_template_association" "test" {
+  notification_template_id = awx_notification_template.test.id
+  job_template_id          = awx_job_template.test.id
+  event                    = %q
+}
+`, event)
+}
+
+func TestResourceNotificationTemplateAssociation(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      fake.config(testNotificationTemplateAssociationConfig("approval")),
+				ExpectError: regexp.MustCompile(`approval notifications are only available`),
+			},
+			{
+				Config: fake.config(testNotificationTemplateAssociationConfig("error")),
+				Check: fake.checkRelated("awx_job_template.test", "job_templates", "notification_templates_error",
+					"awx_notification_template.test"),
+			},
+			{
+				ResourceName:      "awx_notification_template_association.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_notification_template_association.test",
+				ImportState:       true,
+				ImportStateId:     "job_template:test-org/test-job-template:error:test-org/ops",
+				ImportStateVerify: true,
+			},
+			{
+				Config: fake.config(testNotificationTemplateAssociationConfig("success")),
+				Check: resource.ComposeTestCheckFunc(
+					fake.checkRelated("awx_job_template.test", "job_templates", "notification_templates_success",
+						"awx_notification_template.test"),
+					fake.checkNotRelated("awx_job_template.test", "job_templates", "notification_templates_error",
+						"awx_notification_template.test"),
+				),
+			},
+		},
+	})
+}
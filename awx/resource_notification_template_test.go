@@ -0,0 +1,101 @@
+package awx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testNotificationTemplateSlackConfig = `
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_notification_template" "test" {
+  name            = "ops"
+  organization_id = awx_organization.test.id
+
+  slack {
+    token    = "xoxb-secret"
+    channels = ["#ops", "#alerts"]
+  }
+}
+`
+
+const testNotificationTemplateWebhookConfig = `
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_notification_template" "test" {
+  name            = "ops"
+  organization_id = awx_organization.test.id
+
+  webhook {
+    url      = "https://hooks.example.com/awx"
+    password = "secret"
+    headers = {
+      X-Source = "awx"
+    }
+  }
+}
+`
+
+func TestResourceNotificationTemplate(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_notification_template", "notification_templates"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testNotificationTemplateSlackConfig),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_notification_template.test", "notification_type", "slack"),
+					resource.TestCheckResourceAttr("awx_notification_template.test", "slack.0.channels.#", "2"),
+					resource.TestCheckResourceAttr("awx_notification_template.test", "slack.0.token", "xoxb-secret"),
+					fake.checkField("awx_notification_template.test", "notification_templates", "notification_type", "slack"),
+				),
+			},
+			{
+				Config: fake.config(testNotificationTemplateWebhookConfig),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_notification_template.test", "notification_type", "webhook"),
+					resource.TestCheckResourceAttr("awx_notification_template.test", "slack.#", "0"),
+					resource.TestCheckResourceAttr("awx_notification_template.test", "webhook.0.http_method", "POST"),
+					resource.TestCheckResourceAttr("awx_notification_template.test", "webhook.0.headers.X-Source", "awx"),
+					fake.checkField("awx_notification_template.test", "notification_templates", "notification_type", "webhook"),
+				),
+			},
+			{
+				ResourceName:      "awx_notification_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_notification_template.test",
+				ImportState:       true,
+				ImportStateId:     "test-org/ops",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFlattenNotificationConfiguration(t *testing.T) {
+	config := map[string]interface{}{
+		"token":     awxEncrypted,
+		"channels":  []interface{}{"#ops"},
+		"hex_color": nil,
+	}
+	current := map[string]interface{}{
+		"token": "xoxb-secret",
+	}
+	want := map[string]interface{}{
+		"token":    "xoxb-secret",
+		"channels": []interface{}{"#ops"},
+	}
+	if got := flattenNotificationConfiguration(notificationFields["slack"], config, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenNotificationConfiguration() = %v, want %v", got, want)
+	}
+}
@@ -10,6 +10,16 @@
 	}
 
 ```
+
+# Import
+
+Organizations can be imported using the organization ID or the name path
+`<name>`.
+
+```sh
+terraform import awx_organization.default 1
+terraform import awx_organization.default Default
+```
 */
 package awx
 
@@ -30,6 +40,7 @@ func resourceOrganization() *schema.Resource {
 		ReadContext:   resourceOrganizationsRead,
 		UpdateContext: resourceOrganizationsUpdate,
 		DeleteContext: resourceOrganizationsDelete,
+		CustomizeDiff: customizeDiffInstanceGroupIDs,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -53,11 +64,17 @@ func resourceOrganization() *schema.Resource {
 				Optional:    true,
 				Description: "Local absolute file path containing a custom Python virtualenv to use",
 			},
+			"default_environment_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Numeric ID of the execution environment used by default for jobs",
+			},
+			"instance_group_ids": instanceGroupIDsSchema,
 		},
-		//Importer: &schema.ResourceImporter{
-		//	State: schema.ImportStatePassthrough,
-		//},
-		//
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathOrganization),
+		},
+
 		//Timeouts: &schema.ResourceTimeout{
 		//	Create: schema.DefaultTimeout(1 * time.Minute),
 		//	Update: schema.DefaultTimeout(1 * time.Minute),
@@ -72,10 +89,11 @@ func resourceOrganizationsCreate(ctx context.Context, d *schema.ResourceData, m
 	awxService := client.OrganizationsService
 
 	result, err := awxService.CreateOrganization(map[string]interface{}{
-		"name":              d.Get("name").(string),
-		"description":       d.Get("description").(string),
-		"max_hosts":         d.Get("max_hosts").(int),
-		"custom_virtualenv": d.Get("description").(string),
+		"name":                d.Get("name").(string),
+		"description":         d.Get("description").(string),
+		"max_hosts":           d.Get("max_hosts").(int),
+		"custom_virtualenv":   d.Get("custom_virtualenv").(string),
+		"default_environment": optionalID(d.Get("default_environment_id").(int)),
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Template %v", err)
@@ -88,6 +106,13 @@ func resourceOrganizationsCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
+	if err := instanceGroupsUpdate(ctx, d, m, "organizations", result.ID); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: Organization instance groups not attached",
+			"Instance groups of Organization with ID %v not attached, %s",
+			result.ID, err.Error(),
+		)
+	}
 	return resourceOrganizationsRead(ctx, d, m)
 }
 
@@ -108,10 +133,11 @@ func resourceOrganizationsUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	_, err = awxService.UpdateOrganization(id, map[string]interface{}{
-		"name":              d.Get("name").(string),
-		"description":       d.Get("description").(string),
-		"max_hosts":         d.Get("max_hosts").(int),
-		"custom_virtualenv": d.Get("description").(string),
+		"name":                d.Get("name").(string),
+		"description":         d.Get("description").(string),
+		"max_hosts":           d.Get("max_hosts").(int),
+		"custom_virtualenv":   d.Get("custom_virtualenv").(string),
+		"default_environment": optionalID(d.Get("default_environment_id").(int)),
 	}, map[string]string{})
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -122,6 +148,9 @@ func resourceOrganizationsUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diags
 	}
 
+	if err := instanceGroupsUpdate(ctx, d, m, "organizations", id); err != nil {
+		return buildDiagUpdateFail("organization instance groups", id, err)
+	}
 	return resourceOrganizationsRead(ctx, d, m)
 }
 
@@ -140,6 +169,12 @@ func resourceOrganizationsRead(ctx context.Context, d *schema.ResourceData, m in
 
 	}
 	d = setOrganizationsResourceData(d, res)
+	if err := setDefaultEnvironmentResourceData(ctx, d, m, "organizations", id); err != nil {
+		return buildDiagNotFoundFail("Organization", id, err)
+	}
+	if err := setInstanceGroupsResourceData(ctx, d, m, "organizations", id); err != nil {
+		return buildDiagNotFoundFail("organization instance groups", id, err)
+	}
 	return nil
 }
 
@@ -0,0 +1,50 @@
+package awx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceOrganization(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_organization", "organizations"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(`
+resource "awx_organization" "test" {
+  name      = "test-org"
+  max_hosts = 10
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_organization.test", "name", "test-org"),
+					resource.TestCheckResourceAttr("awx_organization.test", "max_hosts", "10"),
+					fake.checkField("awx_organization.test", "organizations", "max_hosts", 10),
+				),
+			},
+			{
+				Config: fake.config(`
+resource "awx_organization" "test" {
+  name              = "test-org"
+  description       = "updated"
+  max_hosts         = 20
+  custom_virtualenv = "/var/lib/awx/venv/custom"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_organization.test", "description", "updated"),
+					fake.checkField("awx_organization.test", "organizations", "max_hosts", 20),
+					fake.checkField("awx_organization.test", "organizations", "custom_virtualenv", "/var/lib/awx/venv/custom"),
+				),
+			},
+			{
+				ResourceName:      "awx_organization.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
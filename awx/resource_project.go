@@ -19,6 +19,16 @@
 	}
 
 ```
+
+# Import
+
+Projects can be imported using the project ID or the name path
+`<organization>/<name>`.
+
+```sh
+terraform import awx_project.base_service_config 8
+terraform import awx_project.base_service_config Default/my-project
+```
 */
 package awx
 
@@ -109,9 +119,14 @@ func resourceProject() *schema.Resource {
 				Optional: true,
 				Default:  0,
 			},
+			"default_environment_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Numeric ID of the execution environment used by default for jobs and project updates",
+			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByNamePath(namePathProject),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -157,6 +172,7 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 		"scm_update_on_launch":     d.Get("scm_update_on_launch").(bool),
 		"scm_update_cache_timeout": d.Get("scm_update_cache_timeout").(int),
+		"default_environment":      optionalID(d.Get("default_environment_id").(int)),
 	}, map[string]string{})
 	if err != nil {
 		return buildDiagnosticsMessage("Create: Project not created", "Project with name %s  in the Organization ID %v not created, %s", projectName, orgID, err.Error())
@@ -193,6 +209,7 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			"organization":             d.Get("organization_id").(int),
 			"scm_update_on_launch":     d.Get("scm_update_on_launch").(bool),
 			"scm_update_cache_timeout": d.Get("scm_update_cache_timeout").(int),
+			"default_environment":      optionalID(d.Get("default_environment_id").(int)),
 		}
 	} else {
 		params = map[string]interface{}{
@@ -207,6 +224,7 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			"organization":             d.Get("organization_id").(int),
 			"scm_update_on_launch":     d.Get("scm_update_on_launch").(bool),
 			"scm_update_cache_timeout": d.Get("scm_update_cache_timeout").(int),
+			"default_environment":      optionalID(d.Get("default_environment_id").(int)),
 		}
 	}
 	_, err := awxService.UpdateProject(id, params, map[string]string{})
@@ -231,6 +249,9 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return buildDiagNotFoundFail("project", id, err)
 	}
 	d = setProjectResourceData(d, res)
+	if err := setDefaultEnvironmentResourceData(ctx, d, m, "projects", id); err != nil {
+		return buildDiagNotFoundFail("project", id, err)
+	}
 	return diags
 }
 
@@ -0,0 +1,61 @@
+package awx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceProject(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_project", "projects"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_project" "test" {
+  name            = "test-project"
+  scm_type        = "git"
+  scm_url         = "https://github.com/ansible/ansible-tower-samples"
+  organization_id = awx_organization.test.id
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_project.test", "scm_type", "git"),
+					fake.checkField("awx_project.test", "projects", "scm_url", "https://github.com/ansible/ansible-tower-samples"),
+				),
+			},
+			{
+				Config: fake.config(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_project" "test" {
+  name                 = "test-project"
+  scm_type             = "git"
+  scm_url              = "https://github.com/ansible/ansible-tower-samples"
+  scm_branch           = "devel"
+  scm_update_on_launch = true
+  organization_id      = awx_organization.test.id
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_project.test", "scm_branch", "devel"),
+					fake.checkField("awx_project.test", "projects", "scm_update_on_launch", true),
+				),
+			},
+			{
+				ResourceName:            "awx_project.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"local_path"},
+			},
+		},
+	})
+}
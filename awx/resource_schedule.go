@@ -0,0 +1,300 @@
+/*
+*TBD*
+
+# Example Usage
+
+```hcl
+
+	resource "awx_schedule" "nightly_patching" {
+	  name                    = "nightly-patching"
+	  unified_job_template_id = awx_job_template.patching.id
+	  rrule                   = "DTSTART;TZID=Europe/Berlin:20230101T020000 RRULE:FREQ=DAILY;INTERVAL=1"
+	  limit                   = "webservers"
+	  extra_data = jsonencode({
+	    reboot = true
+	  })
+	}
+
+```
+
+# Import
+
+Schedules can be imported using the schedule ID or the name path
+`<template>/<name>`.
+
+```sh
+terraform import awx_schedule.nightly_patching 18
+terraform import awx_schedule.nightly_patching patching/nightly-patching
+```
+*/
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// awxSchedule is the subset of the AWX schedule object managed by the
+// provider.
+type awxSchedule struct {
+	ID                 int                    `json:"id"`
+	Name               string                 `json:"name"`
+	Description        string                 `json:"description"`
+	UnifiedJobTemplate int                    `json:"unified_job_template"`
+	Rrule              string                 `json:"rrule"`
+	Enabled            bool                   `json:"enabled"`
+	ExtraData          map[string]interface{} `json:"extra_data"`
+	Inventory          int                    `json:"inventory"`
+	Limit              string                 `json:"limit"`
+	NextRun            string                 `json:"next_run"`
+}
+
+func resourceSchedule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScheduleCreate,
+		ReadContext:   resourceScheduleRead,
+		UpdateContext: resourceScheduleUpdate,
+		DeleteContext: resourceScheduleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of this schedule",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Optional description of this schedule.",
+			},
+			"unified_job_template_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the job template, workflow job template, project or inventory source to launch",
+			},
+			"rrule": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateRRule,
+				Description:  "RFC 5545 recurrence rule, e.g. DTSTART;TZID=Europe/Berlin:20230101T020000 RRULE:FREQ=DAILY;INTERVAL=1",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the schedule launches jobs",
+			},
+			"extra_data": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.StringIsJSON,
+				StateFunc:    normalizeJsonYaml,
+				Description:  "JSON encoded extra variables, the template has to prompt for variables on launch",
+			},
+			"inventory_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Inventory applied as a prompt, the template has to prompt for the inventory on launch",
+			},
+			"limit": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Limit applied as a prompt, the template has to prompt for the limit on launch",
+			},
+			"credential_ids": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "Credentials applied as a prompt, the template has to prompt for credentials on launch",
+			},
+			"next_run": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the next launch",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathSchedule),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+	}
+}
+
+func schedulePayload(d *schema.ResourceData) (map[string]interface{}, error) {
+	payload := map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"rrule":       d.Get("rrule").(string),
+		"enabled":     d.Get("enabled").(bool),
+		"limit":       d.Get("limit").(string),
+		"inventory":   nil,
+		"extra_data":  map[string]interface{}{},
+	}
+	if inventoryID := d.Get("inventory_id").(int); inventoryID > 0 {
+		payload["inventory"] = inventoryID
+	}
+	if extraData := d.Get("extra_data").(string); extraData != "" {
+		var data map[string]interface{}
+		if err := json.Unmarshal([]byte(extraData), &data); err != nil {
+			return nil, fmt.Errorf("extra_data is not a JSON object: %s", err)
+		}
+		payload["extra_data"] = data
+	}
+	return payload, nil
+}
+
+func resourceScheduleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	payload, err := schedulePayload(d)
+	if err != nil {
+		return buildDiagCreateFail("schedule", err)
+	}
+	payload["unified_job_template"] = d.Get("unified_job_template_id").(int)
+
+	var schedule awxSchedule
+	if err := getAPIClient(m).post(ctx, "/api/v2/schedules/", payload, &schedule); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: Schedule not created",
+			"Schedule with name %s for template ID %v not created, %s",
+			d.Get("name").(string), d.Get("unified_job_template_id").(int), err.Error(),
+		)
+	}
+	d.SetId(strconv.Itoa(schedule.ID))
+
+	credentials := d.Get("credential_ids").(*schema.Set).List()
+	if err := scheduleCredentialsUpdate(ctx, m, schedule.ID, credentials, false); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: Schedule credentials not added",
+			"Credentials of schedule with ID %v not added, %s",
+			schedule.ID, err.Error(),
+		)
+	}
+	return resourceScheduleRead(ctx, d, m)
+}
+
+// scheduleCredentialsUpdate adds or removes credentials prompted by a
+// schedule.
+func scheduleCredentialsUpdate(ctx context.Context, m interface{}, scheduleID int, credentials []interface{}, remove bool) error {
+	for _, v := range credentials {
+		payload := map[string]interface{}{
+			"id": v.(int),
+		}
+		if remove {
+			payload["disassociate"] = true // presence of key triggers removal
+		}
+		if err := getAPIClient(m).post(ctx, fmt.Sprintf("/api/v2/schedules/%d/credentials/", scheduleID), payload, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceScheduleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Update Schedule", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	payload, err := schedulePayload(d)
+	if err != nil {
+		return buildDiagUpdateFail("schedule", id, err)
+	}
+	if err := getAPIClient(m).patch(ctx, fmt.Sprintf("/api/v2/schedules/%d/", id), payload, nil); err != nil {
+		return buildDiagUpdateFail("schedule", id, err)
+	}
+
+	if d.HasChange("credential_ids") {
+		o, n := d.GetChange("credential_ids")
+		oldSet, newSet := o.(*schema.Set), n.(*schema.Set)
+		if err := scheduleCredentialsUpdate(ctx, m, id, oldSet.Difference(newSet).List(), true); err != nil {
+			return buildDiagUpdateFail("schedule credentials", id, err)
+		}
+		if err := scheduleCredentialsUpdate(ctx, m, id, newSet.Difference(oldSet).List(), false); err != nil {
+			return buildDiagUpdateFail("schedule credentials", id, err)
+		}
+	}
+	return resourceScheduleRead(ctx, d, m)
+}
+
+func resourceScheduleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read Schedule", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	var schedule awxSchedule
+	err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/schedules/%d/", id), nil, &schedule)
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return buildDiagNotFoundFail("schedule", id, err)
+	}
+
+	credentials, err := getAPIClient(m).list(ctx, fmt.Sprintf("/api/v2/schedules/%d/credentials/", id), nil)
+	if err != nil {
+		return buildDiagNotFoundFail("schedule credentials", id, err)
+	}
+
+	d = setScheduleResourceData(d, &schedule, credentials)
+	return diags
+}
+
+func resourceScheduleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Delete Schedule", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := getAPIClient(m).delete(ctx, fmt.Sprintf("/api/v2/schedules/%d/", id))
+	if err != nil && !isNotFound(err) {
+		return buildDiagDeleteFail("Schedule", fmt.Sprintf("ScheduleID %v, got %s ", id, err.Error()))
+	}
+	d.SetId("")
+	return diags
+}
+
+func setScheduleResourceData(d *schema.ResourceData, r *awxSchedule, credentials []map[string]interface{}) *schema.ResourceData {
+	d.Set("name", r.Name)
+	d.Set("description", r.Description)
+	d.Set("unified_job_template_id", r.UnifiedJobTemplate)
+	d.Set("rrule", r.Rrule)
+	d.Set("enabled", r.Enabled)
+	d.Set("inventory_id", r.Inventory)
+	d.Set("limit", r.Limit)
+	d.Set("next_run", r.NextRun)
+
+	extraData := ""
+	if len(r.ExtraData) > 0 {
+		b, _ := json.Marshal(r.ExtraData)
+		extraData = normalizeJsonYaml(string(b))
+	}
+	d.Set("extra_data", extraData)
+
+	var credentialIDs []interface{}
+	for _, c := range credentials {
+		if id, ok := c["id"].(float64); ok {
+			credentialIDs = append(credentialIDs, int(id))
+		}
+	}
+	d.Set("credential_ids", schema.NewSet(schema.HashInt, credentialIDs))
+
+	d.SetId(strconv.Itoa(r.ID))
+	return d
+}
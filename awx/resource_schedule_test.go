@@ -0,0 +1,67 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testScheduleConfig(rrule, limit string) string {
+	return testJobTemplateConfig("hello_world.yml") + fmt.Sprintf(`
+resource "awx_credential" "test" {
+  name               = "test-credential"
+  organization_id    = awx_organization.test.id
+  credential_type_id = 1
+  inputs             = jsonencode({ username = "alice" })
+}
+
+resource "awx_schedule" "test" {
+  name                    = "nightly"
+  unified_job_template_id = awx_job_template.test.id
+  rrule                   = %q
+  limit                   = %q
+  inventory_id            = awx_inventory.test.id
+  credential_ids          = [awx_credential.test.id]
+  extra_data = jsonencode({
+    reboot = true
+  })
+}
+`, rrule, limit)
+}
+
+func TestResourceSchedule(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_schedule", "schedules"),
+		Steps: []resource.TestStep{
+			{
+				Config:      fake.config(testScheduleConfig("DTSTART:20230101T020000 RRULE:FREQ=DAILY", "web")),
+				ExpectError: regexp.MustCompile(`neither a TZID nor a UTC time`),
+			},
+			{
+				Config: fake.config(testScheduleConfig("DTSTART;TZID=Europe/Berlin:20230101T020000 RRULE:FREQ=DAILY;INTERVAL=1", "web")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_schedule.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("awx_schedule.test", "credential_ids.#", "1"),
+					fake.checkField("awx_schedule.test", "schedules", "limit", "web"),
+					fake.checkRelated("awx_schedule.test", "schedules", "credentials", "awx_credential.test"),
+				),
+			},
+			{
+				Config: fake.config(testScheduleConfig("DTSTART;TZID=Europe/Berlin:20230101T030000 RRULE:FREQ=WEEKLY;BYDAY=SA", "db")),
+				Check: resource.ComposeTestCheckFunc(
+					fake.checkField("awx_schedule.test", "schedules", "rrule", "DTSTART;TZID=Europe/Berlin:20230101T030000 RRULE:FREQ=WEEKLY;BYDAY=SA"),
+					fake.checkField("awx_schedule.test", "schedules", "limit", "db"),
+				),
+			},
+			{
+				ResourceName:      "awx_schedule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
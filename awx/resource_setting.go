@@ -1,6 +1,8 @@
 /*
 This resource configure generic AWX settings.
-Please note that resource deletion only delete object from terraform state and do not reset setting to his initial value.
+The value is read back from AWX, so changes made outside of Terraform are reported as drift.
+Secret settings such as passwords are returned encrypted by AWX, the configured value is kept for them and drift is not detected.
+By default resource deletion only delete object from terraform state, set `restore_default_on_destroy` to reset the setting to its default value.
 
 See available settings list here: https://docs.ansible.com/ansible-tower/latest/html/towerapi/api_ref.html#/Settings/Settings_settings_update
 
@@ -24,8 +26,9 @@ See available settings list here: https://docs.ansible.com/ansible-tower/latest/
 	}
 
 	resource "awx_setting" "schedule_max_jobs" {
-	  name  = "SCHEDULE_MAX_JOBS"
-	  value = 15
+	  name                       = "SCHEDULE_MAX_JOBS"
+	  value                      = 15
+	  restore_default_on_destroy = true
 	}
 
 	resource "awx_setting" "remote_host_headers" {
@@ -40,12 +43,21 @@ See available settings list here: https://docs.ansible.com/ansible-tower/latest/
 	}
 
 ```
+
+# Import
+
+Settings can be imported using the setting name.
+
+```sh
+terraform import awx_setting.schedule_max_jobs SCHEDULE_MAX_JOBS
+```
 */
 package awx
 
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -69,7 +81,15 @@ func resourceSetting() *schema.Resource {
 			"value": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "Value to be modified for given setting.",
+				Sensitive:   true,
+				StateFunc:   normalizeSettingValue,
+				Description: "Value to be modified for given setting. JSON objects, arrays, booleans and numbers are sent decoded, anything else as a string.",
+			},
+			"restore_default_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Reset the setting to its default value when the resource is destroyed",
 			},
 		},
 		Importer: &schema.ResourceImporter{
@@ -86,6 +106,45 @@ func resourceSetting() *schema.Resource {
 
 type setting map[string]string
 
+const settingsPath = "/api/v2/settings/all/"
+
+// encodeSettingValue decodes the value of a setting as sent to AWX: JSON
+// objects, arrays, booleans and numbers are decoded, anything else is sent
+// as the raw string.
+func encodeSettingValue(value string) interface{} {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return value
+	}
+	switch decoded.(type) {
+	case map[string]interface{}, []interface{}, bool, float64:
+		return decoded
+	}
+	return value
+}
+
+// flattenSettingValue is the inverse of encodeSettingValue, JSON values are
+// encoded compactly with sorted object keys.
+func flattenSettingValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+// normalizeSettingValue normalizes a configured value the way it is read
+// back from AWX, so equivalent JSON does not show a diff.
+func normalizeSettingValue(value interface{}) string {
+	return flattenSettingValue(encodeSettingValue(value.(string)))
+}
+
 func resourceSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*awx.AWX)
 	awxService := client.SettingService
@@ -98,31 +157,11 @@ func resourceSettingUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		)
 	}
 
-	var map_decoded map[string]interface{}
-	var array_decoded []interface{}
-	var formatted_value interface{}
-
 	name := d.Get("name").(string)
 	value := d.Get("value").(string)
 
-	// Attempt to unmarshall string into a map
-	err = json.Unmarshal([]byte(value), &map_decoded)
-
-	if err != nil {
-		// Attempt to unmarshall string into an array
-		err = json.Unmarshal([]byte(value), &array_decoded)
-
-		if err != nil {
-			formatted_value = value
-		} else {
-			formatted_value = array_decoded
-		}
-	} else {
-		formatted_value = map_decoded
-	}
-
 	payload := map[string]interface{}{
-		name: formatted_value,
+		name: encodeSettingValue(value),
 	}
 
 	_, err = awxService.UpdateSettings("all", payload, make(map[string]string))
@@ -139,25 +178,55 @@ func resourceSettingUpdate(ctx context.Context, d *schema.ResourceData, m interf
 
 func resourceSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
-	awxService := client.SettingService
 
-	_, err := awxService.GetSettingsBySlug("all", make(map[string]string))
-	if err != nil {
+	var settings map[string]interface{}
+	if err := getAPIClient(m).get(ctx, settingsPath, nil, &settings); err != nil {
 		return buildDiagnosticsMessage(
 			"Unable to fetch settings",
 			"Unable to load settings with slug all: got %s", err.Error(),
 		)
 	}
 
+	value, ok := settings[d.Id()]
+	if !ok {
+		d.SetId("")
+		return diags
+	}
+
 	d.Set("name", d.Id())
-	d.Set("value", d.Get("value").(string))
+	if value != awxEncrypted {
+		// AWX does not return secret settings, the configured value is kept.
+		d.Set("value", flattenSettingValue(value))
+	}
 	return diags
 }
 
 func resourceSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
+	if d.Get("restore_default_on_destroy").(bool) {
+		name := d.Id()
+		var metadata struct {
+			Actions struct {
+				PUT map[string]map[string]interface{} `json:"PUT"`
+			} `json:"actions"`
+		}
+		if err := getAPIClient(m).options(ctx, settingsPath, &metadata); err != nil {
+			return buildDiagDeleteFail("setting", fmt.Sprintf("failed to fetch the default of %s, got %s", name, err.Error()))
+		}
+		field, ok := metadata.Actions.PUT[name]
+		if !ok {
+			return buildDiagDeleteFail("setting", fmt.Sprintf("%s is not a writable setting", name))
+		}
+		def, ok := field["default"]
+		if !ok {
+			return buildDiagDeleteFail("setting", fmt.Sprintf("%s has no default value", name))
+		}
+		if err := getAPIClient(m).patch(ctx, settingsPath, map[string]interface{}{name: def}, nil); err != nil {
+			return buildDiagDeleteFail("setting", fmt.Sprintf("failed to restore the default of %s, got %s", name, err.Error()))
+		}
+	}
+
 	d.SetId("")
 	return diags
 }
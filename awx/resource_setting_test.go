@@ -0,0 +1,139 @@
+package awx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceSetting(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(`
+resource "awx_setting" "test" {
+  name  = "SCHEDULE_MAX_JOBS"
+  value = 15
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_setting.test", "id", "SCHEDULE_MAX_JOBS"),
+					fake.checkSetting("SCHEDULE_MAX_JOBS", "15"),
+				),
+			},
+			{
+				Config: fake.config(`
+resource "awx_setting" "test" {
+  name  = "REMOTE_HOST_HEADERS"
+  value = jsonencode(["HTTP_X_FORWARDED_FOR", "REMOTE_ADDR"])
+}
+`),
+				Check: fake.checkSetting("REMOTE_HOST_HEADERS", []interface{}{"HTTP_X_FORWARDED_FOR", "REMOTE_ADDR"}),
+			},
+			{
+				ResourceName:            "awx_setting.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"restore_default_on_destroy"},
+			},
+		},
+	})
+}
+
+func TestSettingValue(t *testing.T) {
+	cases := []struct {
+		value string
+		want  string
+	}{
+		{"15", "15"},
+		{"true", "true"},
+		{"test", "test"},
+		{`"quoted"`, `"quoted"`},
+		{"[\n  \"REMOTE_ADDR\",\n  \"REMOTE_HOST\"\n]", `["REMOTE_ADDR","REMOTE_HOST"]`},
+		{`{"b": 1, "a": {"c": false}}`, `{"a":{"c":false},"b":1}`},
+	}
+	for _, c := range cases {
+		if got := normalizeSettingValue(c.value); got != c.want {
+			t.Errorf("normalizeSettingValue(%q) = %q, want %q", c.value, got, c.want)
+		}
+	}
+}
+
+func TestResourceSettingDrift(t *testing.T) {
+	fake := newFakeAWX(t)
+	config := fake.config(`
+resource "awx_setting" "test" {
+  name                       = "SCHEDULE_MAX_JOBS"
+  value                      = 15
+  restore_default_on_destroy = true
+}
+
+resource "awx_setting" "team_map" {
+  name  = "AUTH_LDAP_TEAM_MAP"
+  value = <<EOF
+  {
+    "admins": {"users": true}
+  }
+  EOF
+}
+`)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkSetting("SCHEDULE_MAX_JOBS", "10"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_setting.team_map", "value", `{"admins":{"users":true}}`),
+					fake.checkSetting("AUTH_LDAP_TEAM_MAP", map[string]interface{}{"admins": map[string]interface{}{"users": true}}),
+				),
+			},
+			{
+				// The setting is changed outside of Terraform.
+				PreConfig: func() {
+					fake.mu.Lock()
+					defer fake.mu.Unlock()
+					fake.settings["SCHEDULE_MAX_JOBS"] = float64(20)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  fake.checkSetting("SCHEDULE_MAX_JOBS", "15"),
+			},
+		},
+	})
+}
+
+func TestResourceSettingSecret(t *testing.T) {
+	fake := newFakeAWX(t)
+	config := fake.config(`
+resource "awx_setting" "test" {
+  name  = "AUTH_LDAP_BIND_PASSWORD"
+  value = "secret"
+}
+`)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  fake.checkSetting("AUTH_LDAP_BIND_PASSWORD", "secret"),
+			},
+			{
+				// AWX does not return secret settings.
+				PreConfig: func() {
+					fake.mu.Lock()
+					defer fake.mu.Unlock()
+					fake.settings["AUTH_LDAP_BIND_PASSWORD"] = awxEncrypted
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
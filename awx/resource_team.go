@@ -40,6 +40,16 @@
 	}
 
 ```
+
+# Import
+
+Teams can be imported using the team ID or the name path
+`<organization>/<name>`.
+
+```sh
+terraform import awx_team.admins_team 4
+terraform import awx_team.admins_team Default/admins-team
+```
 */
 package awx
 
@@ -93,7 +103,7 @@ func resourceTeam() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateByNamePath(namePathTeam),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -0,0 +1,60 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testTeamConfig(role string) string {
+	return fmt.Sprintf(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+data "awx_organization_role" "test" {
+  name            = %q
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_team" "test" {
+  name            = "test-team"
+  organization_id = awx_organization.test.id
+
+  role_entitlement {
+    role_id = data.awx_organization_role.test.id
+  }
+}
+`, role)
+}
+
+func TestResourceTeam(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_team", "teams"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testTeamConfig("Admin")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_team.test", "name", "test-team"),
+					resource.TestCheckResourceAttr("awx_team.test", "role_entitlement.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("awx_team.test", "role_entitlement.*.role_id", "data.awx_organization_role.test", "id"),
+				),
+			},
+			{
+				Config: fake.config(testTeamConfig("Member")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_team.test", "role_entitlement.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("awx_team.test", "role_entitlement.*.role_id", "data.awx_organization_role.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "awx_team.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,202 @@
+/*
+*TBD*
+
+# Example Usage
+
+```hcl
+
+	resource "awx_user" "jdoe" {
+	  username   = "jdoe"
+	  email      = "jdoe@example.com"
+	  first_name = "John"
+	  last_name  = "Doe"
+	  password   = var.jdoe_password
+	}
+
+```
+
+# Import
+
+Users can be imported using the user ID or the username.
+
+```sh
+terraform import awx_user.jdoe 3
+terraform import awx_user.jdoe jdoe
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// awxUser is the subset of the AWX user object managed by the provider.
+type awxUser struct {
+	ID              int    `json:"id"`
+	Username        string `json:"username"`
+	Email           string `json:"email"`
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+	IsSuperuser     bool   `json:"is_superuser"`
+	IsSystemAuditor bool   `json:"is_system_auditor"`
+}
+
+func resourceUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
+
+		Schema: map[string]*schema.Schema{
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Username of this user. Letters, digits and @/./+/-/_ only.",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Email address of this user.",
+			},
+			"first_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "First name of this user.",
+			},
+			"last_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Last name of this user.",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password of this user. AWX never returns it, so changes made outside of Terraform are not detected.",
+			},
+			"is_superuser": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether this user has all permissions without explicitly assigning them.",
+			},
+			"is_system_auditor": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether this user can read everything in AWX.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathUser),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+	}
+}
+
+func userPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"username":          d.Get("username").(string),
+		"email":             d.Get("email").(string),
+		"first_name":        d.Get("first_name").(string),
+		"last_name":         d.Get("last_name").(string),
+		"is_superuser":      d.Get("is_superuser").(bool),
+		"is_system_auditor": d.Get("is_system_auditor").(bool),
+	}
+}
+
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	payload := userPayload(d)
+	if password, ok := d.GetOk("password"); ok {
+		payload["password"] = password.(string)
+	}
+
+	var user awxUser
+	if err := getAPIClient(m).post(ctx, "/api/v2/users/", payload, &user); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: User not created",
+			"User with username %s not created, %s",
+			d.Get("username").(string), err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(user.ID))
+	return resourceUserRead(ctx, d, m)
+}
+
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Update User", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	payload := userPayload(d)
+	if d.HasChange("password") {
+		payload["password"] = d.Get("password").(string)
+	}
+
+	if err := getAPIClient(m).patch(ctx, fmt.Sprintf("/api/v2/users/%d/", id), payload, nil); err != nil {
+		return buildDiagUpdateFail("user", id, err)
+	}
+	return resourceUserRead(ctx, d, m)
+}
+
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read User", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	var user awxUser
+	err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/users/%d/", id), nil, &user)
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return buildDiagNotFoundFail("user", id, err)
+	}
+
+	d = setUserResourceData(d, &user)
+	return diags
+}
+
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Delete User", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := getAPIClient(m).delete(ctx, fmt.Sprintf("/api/v2/users/%d/", id))
+	if err != nil && !isNotFound(err) {
+		return buildDiagDeleteFail("User", fmt.Sprintf("UserID %v, got %s ", id, err.Error()))
+	}
+	d.SetId("")
+	return diags
+}
+
+func setUserResourceData(d *schema.ResourceData, r *awxUser) *schema.ResourceData {
+	d.Set("username", r.Username)
+	d.Set("email", r.Email)
+	d.Set("first_name", r.FirstName)
+	d.Set("last_name", r.LastName)
+	d.Set("is_superuser", r.IsSuperuser)
+	d.Set("is_system_auditor", r.IsSystemAuditor)
+	d.SetId(strconv.Itoa(r.ID))
+	return d
+}
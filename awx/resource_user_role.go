@@ -0,0 +1,166 @@
+/*
+*TBD*
+
+# Example Usage
+
+```hcl
+
+	data "awx_organization" "default" {
+	  name = "Default"
+	}
+
+	data "awx_organization_role" "default_member" {
+	  name            = "Member"
+	  organization_id = data.awx_organization.default.id
+	}
+
+	data "awx_team" "ops" {
+	  name = "ops"
+	}
+
+	data "awx_team_role" "ops_member" {
+	  name    = "Member"
+	  team_id = data.awx_team.ops.id
+	}
+
+	resource "awx_user_role" "jdoe_default" {
+	  user_id = awx_user.jdoe.id
+	  role_id = data.awx_organization_role.default_member.id
+	}
+
+	resource "awx_user_role" "jdoe_ops" {
+	  user_id = awx_user.jdoe.id
+	  role_id = data.awx_team_role.ops_member.id
+	}
+
+```
+
+# Import
+
+User roles can be imported using the user and the role ID separated by a
+colon. The user is given by its ID or its username, roles have no name of
+their own and are always given by their ID.
+
+```sh
+terraform import awx_user_role.jdoe_ops 3:57
+terraform import awx_user_role.jdoe_ops jdoe:57
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceUserRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserRoleCreate,
+		ReadContext:   resourceUserRoleRead,
+		DeleteContext: resourceUserRoleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the user",
+			},
+			"role_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the role granted to the user, e.g. the member role of an organization or team",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceUserRoleImport,
+		},
+	}
+}
+
+// userRoleUpdate grants or revokes a role of a user, like
+// roleEntitlementUpdate does for teams.
+func userRoleUpdate(ctx context.Context, m interface{}, userID, roleID int, remove bool) error {
+	payload := map[string]interface{}{
+		"id": roleID,
+	}
+	if remove {
+		payload["disassociate"] = true // presence of key triggers removal
+	}
+	return getAPIClient(m).post(ctx, fmt.Sprintf("/api/v2/users/%d/roles/", userID), payload, nil)
+}
+
+func resourceUserRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	userID := d.Get("user_id").(int)
+	roleID := d.Get("role_id").(int)
+
+	if err := userRoleUpdate(ctx, m, userID, roleID, false); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: User role not granted",
+			"Fail to grant role with ID %v to user with ID %v, got %s",
+			roleID, userID, err.Error(),
+		)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d", userID, roleID))
+	return resourceUserRoleRead(ctx, d, m)
+}
+
+func resourceUserRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	userID := d.Get("user_id").(int)
+	roleID := d.Get("role_id").(int)
+
+	roles, err := getAPIClient(m).list(ctx, fmt.Sprintf("/api/v2/users/%d/roles/", userID), map[string]string{
+		"id": strconv.Itoa(roleID),
+	})
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return buildDiagNotFoundFail("user roles", userID, err)
+	}
+	if len(roles) == 0 {
+		d.SetId("")
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d", userID, roleID))
+	return diags
+}
+
+func resourceUserRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	userID := d.Get("user_id").(int)
+	roleID := d.Get("role_id").(int)
+
+	err := userRoleUpdate(ctx, m, userID, roleID, true)
+	if err != nil && !isNotFound(err) {
+		return buildDiagDeleteFail(
+			"User role",
+			fmt.Sprintf("RoleID %v from UserID %v, got %s ", roleID, userID, err.Error()),
+		)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceUserRoleImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids, err := splitImportID(m, d.Id(),
+		importIDPart{"<user>", &namePathUser},
+		importIDPart{"<role_id>", nil},
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.Set("user_id", ids[0])
+	d.Set("role_id", ids[1])
+	return []*schema.ResourceData{d}, nil
+}
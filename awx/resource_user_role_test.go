@@ -0,0 +1,71 @@
+package awx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceUserRole(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_team" "test" {
+  name            = "test-team"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_user" "test" {
+  username = "jdoe"
+  password = "secret"
+}
+
+data "awx_organization_role" "member" {
+  name            = "Member"
+  organization_id = awx_organization.test.id
+}
+
+data "awx_team_role" "member" {
+  name    = "Member"
+  team_id = awx_team.test.id
+}
+
+resource "awx_user_role" "organization" {
+  user_id = awx_user.test.id
+  role_id = data.awx_organization_role.member.id
+}
+
+resource "awx_user_role" "team" {
+  user_id = awx_user.test.id
+  role_id = data.awx_team_role.member.id
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					fake.checkRelated("awx_user.test", "users", "roles", "data.awx_organization_role.member"),
+					fake.checkRelated("awx_user.test", "users", "roles", "data.awx_team_role.member"),
+				),
+			},
+			{
+				ResourceName:      "awx_user_role.team",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName: "awx_user_role.team",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return "jdoe:" + s.RootModule().Resources["data.awx_team_role.member"].Primary.ID, nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
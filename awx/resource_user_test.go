@@ -0,0 +1,65 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testUserConfig(email string, superuser bool) string {
+	return fmt.Sprintf(`
+resource "awx_user" "test" {
+  username     = "jdoe"
+  email        = %q
+  first_name   = "John"
+  last_name    = "Doe"
+  password     = "secret"
+  is_superuser = %t
+}
+
+data "awx_user" "test" {
+  username = awx_user.test.username
+}
+`, email, superuser)
+}
+
+func TestResourceUser(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_user", "users"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testUserConfig("jdoe@example.com", false)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_user.test", "username", "jdoe"),
+					fake.checkField("awx_user.test", "users", "password", "secret"),
+					resource.TestCheckResourceAttrPair("data.awx_user.test", "id", "awx_user.test", "id"),
+					resource.TestCheckResourceAttr("data.awx_user.test", "email", "jdoe@example.com"),
+				),
+			},
+			{
+				Config: fake.config(testUserConfig("john.doe@example.com", true)),
+				Check: resource.ComposeTestCheckFunc(
+					fake.checkField("awx_user.test", "users", "email", "john.doe@example.com"),
+					fake.checkField("awx_user.test", "users", "is_superuser", true),
+					resource.TestCheckResourceAttr("data.awx_user.test", "is_superuser", "true"),
+				),
+			},
+			{
+				ResourceName:            "awx_user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			{
+				ResourceName:            "awx_user.test",
+				ImportState:             true,
+				ImportStateId:           "jdoe",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
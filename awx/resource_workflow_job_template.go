@@ -12,6 +12,16 @@
 	}
 
 ```
+
+# Import
+
+Workflow job templates can be imported using the workflow job template ID or the name path
+`<organization>/<name>`.
+
+```sh
+terraform import awx_workflow_job_template.default 15
+terraform import awx_workflow_job_template.default Default/my-workflow
+```
 */
 package awx
 
@@ -22,6 +32,7 @@ import (
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	awx "github.com/mrcrilly/goawx/client"
 )
@@ -63,6 +74,8 @@ func resourceWorkflowJobTemplate() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"survey": surveySchema,
+			"labels": labelsSchema,
 			"allow_simultaneous": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -114,10 +127,11 @@ func resourceWorkflowJobTemplate() *schema.Resource {
 				Default:  "",
 			},
 		},
-		//Importer: &schema.ResourceImporter{
-		//	State: schema.ImportStatePassthrough,
-		//},
-		//
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathWorkflowJobTemplate),
+		},
+		CustomizeDiff: customdiff.All(customizeDiffSurvey, customizeDiffLabels),
+
 		//Timeouts: &schema.ResourceTimeout{
 		//	Create: schema.DefaultTimeout(1 * time.Minute),
 		//	Update: schema.DefaultTimeout(1 * time.Minute),
@@ -159,6 +173,22 @@ func resourceWorkflowJobTemplateCreate(ctx context.Context, d *schema.ResourceDa
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
+	if err := labelsUpdate(ctx, d, m, "workflow_job_templates", result.ID); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: WorkflowJobTemplate labels not attached",
+			"Labels of WorkflowJobTemplate with ID %v not attached, %s",
+			result.ID, err.Error(),
+		)
+	}
+	if _, ok := d.GetOk("survey"); ok {
+		if err := surveySpecUpdate(ctx, d, m, "workflow_job_templates", result.ID); err != nil {
+			return buildDiagnosticsMessage(
+				"Create: WorkflowJobTemplate survey not created",
+				"Survey of WorkflowJobTemplate with ID %v not created, %s",
+				result.ID, err.Error(),
+			)
+		}
+	}
 	return resourceWorkflowJobTemplateRead(ctx, d, m)
 }
 
@@ -203,6 +233,14 @@ func resourceWorkflowJobTemplateUpdate(ctx context.Context, d *schema.ResourceDa
 		return diags
 	}
 
+	if err := labelsUpdate(ctx, d, m, "workflow_job_templates", id); err != nil {
+		return buildDiagUpdateFail("workflow job template labels", id, err)
+	}
+	if d.HasChange("survey") {
+		if err := surveySpecUpdate(ctx, d, m, "workflow_job_templates", id); err != nil {
+			return buildDiagUpdateFail("workflow job template survey", id, err)
+		}
+	}
 	return resourceWorkflowJobTemplateRead(ctx, d, m)
 }
 
@@ -221,6 +259,12 @@ func resourceWorkflowJobTemplateRead(ctx context.Context, d *schema.ResourceData
 
 	}
 	d = setWorkflowJobTemplateResourceData(d, res)
+	if err := setLabelsResourceData(ctx, d, m, "workflow_job_templates", id); err != nil {
+		return buildDiagNotFoundFail("workflow job template labels", id, err)
+	}
+	if err := setSurveyResourceData(ctx, d, m, "workflow_job_templates", id); err != nil {
+		return buildDiagNotFoundFail("workflow job template survey", id, err)
+	}
 	return nil
 }
 
@@ -246,8 +290,8 @@ func setWorkflowJobTemplateResourceData(d *schema.ResourceData, r *awx.WorkflowJ
 
 	d.Set("name", r.Name)
 	d.Set("description", r.Description)
-	d.Set("organization_id", strconv.Itoa(r.Organization))
-	d.Set("inventory_id", strconv.Itoa(r.Inventory))
+	d.Set("organization_id", r.Organization)
+	d.Set("inventory_id", r.Inventory)
 	d.Set("survey_enabled", r.SurveyEnabled)
 	d.Set("allow_simultaneous", r.AllowSimultaneous)
 	d.Set("ask_variables_on_launch", r.AskVariablesOnLaunch)
@@ -0,0 +1,740 @@
+/*
+Manages all nodes of a workflow job template and the edges between them in a
+single resource. Nodes are matched to the nodes in AWX by their identifier,
+on apply only the differences to the live graph are applied. Nodes of the
+workflow which are not listed are deleted, so do not combine this resource
+with awx_workflow_job_template_node resources of the same workflow.
+
+Edges refer to the identifiers of other nodes in the graph. Cycles, unknown
+identifiers and nodes linked twice by the same parent are rejected at plan
+time.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_workflow_job_template_graph" "release" {
+	  workflow_job_template_id = awx_workflow_job_template.release.id
+
+	  node {
+	    identifier              = "build"
+	    unified_job_template_id = awx_job_template.build.id
+	    success_nodes           = ["test-eu", "test-us"]
+	  }
+
+	  node {
+	    identifier              = "test-eu"
+	    unified_job_template_id = awx_job_template.test.id
+	    limit                   = "eu"
+	    success_nodes           = ["change-approval"]
+	  }
+
+	  node {
+	    identifier              = "test-us"
+	    unified_job_template_id = awx_job_template.test.id
+	    limit                   = "us"
+	    success_nodes           = ["change-approval"]
+	  }
+
+	  node {
+	    identifier    = "change-approval"
+	    success_nodes = ["deploy"]
+
+	    approval {
+	      name    = "Release ${var.release}"
+	      timeout = 86400
+	    }
+	  }
+
+	  node {
+	    identifier              = "deploy"
+	    unified_job_template_id = awx_job_template.deploy.id
+	    extra_data = jsonencode({
+	      release = var.release
+	    })
+	  }
+	}
+
+```
+
+# Import
+
+Workflow job template graphs can be imported using the workflow job template
+ID or the name path `<organization>/<name>` of the workflow job template.
+
+```sh
+terraform import awx_workflow_job_template_graph.release 7
+terraform import awx_workflow_job_template_graph.release Default/release
+```
+*/
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// workflowGraphScalars are the node attributes compared with the live node
+// to decide whether it needs to be updated.
+var workflowGraphScalars = []string{
+	"unified_job_template_id",
+	"all_parents_must_converge",
+	"inventory_id",
+	"extra_data",
+	"scm_branch",
+	"job_type",
+	"job_tags",
+	"skip_tags",
+	"limit",
+	"diff_mode",
+	"verbosity",
+	"execution_environment_id",
+	"forks",
+	"timeout",
+	"job_slice_count",
+}
+
+func workflowGraphEdgeSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Optional:    true,
+		Description: description,
+	}
+}
+
+func resourceWorkflowJobTemplateGraph() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWorkflowJobTemplateGraphCreate,
+		ReadContext:   resourceWorkflowJobTemplateGraphRead,
+		UpdateContext: resourceWorkflowJobTemplateGraphUpdate,
+		DeleteContext: resourceWorkflowJobTemplateGraphDelete,
+		CustomizeDiff: resourceWorkflowJobTemplateGraphCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"workflow_job_template_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the workflow job template",
+			},
+			"node": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Nodes of the workflow",
+				Elem: &schema.Resource{
+					Schema: withWorkflowNodePrompts(map[string]*schema.Schema{
+						"identifier": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							Description:  "Identifier of the node, unique within the workflow",
+						},
+						"unified_job_template_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Numeric ID of the job template, project, inventory source or workflow run by the node, required unless approval is set",
+						},
+						"approval":      workflowApprovalSchema,
+						"success_nodes": workflowGraphEdgeSchema("Identifiers of the nodes run after this node on success"),
+						"failure_nodes": workflowGraphEdgeSchema("Identifiers of the nodes run after this node on failure"),
+						"always_nodes":  workflowGraphEdgeSchema("Identifiers of the nodes run after this node regardless of its result"),
+						"all_parents_must_converge": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether all parents must finish with the expected result before the node runs",
+						},
+						"inventory_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Inventory applied as a prompt, assuming job template prompts for inventory.",
+						},
+						"extra_data": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "",
+							ValidateFunc: validation.StringIsJSON,
+							StateFunc:    normalizeJsonYaml,
+							Description:  "Extra variables applied as a prompt as JSON object",
+						},
+						"scm_branch": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Project branch applied as a prompt",
+						},
+						"job_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "",
+							ValidateFunc: validation.StringInSlice([]string{"", "run", "check"}, false),
+							Description:  "Job type applied as a prompt, one of run or check",
+						},
+						"job_tags": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Job tags applied as a prompt",
+						},
+						"skip_tags": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Skip tags applied as a prompt",
+						},
+						"limit": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Host limit applied as a prompt",
+						},
+						"diff_mode": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Diff mode applied as a prompt",
+						},
+						"verbosity": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(0, 5),
+							Description:  "Verbosity applied as a prompt",
+						},
+					}),
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathWorkflowJobTemplate),
+		},
+	}
+}
+
+// resourceWorkflowJobTemplateGraphCustomizeDiff validates the graph at plan
+// time. Nodes and edges whose identifiers are not known yet are skipped.
+func resourceWorkflowJobTemplateGraphCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	nodes := d.Get("node").([]interface{})
+	identifiers := make(map[string]bool)
+	allKnown := true
+	for i, v := range nodes {
+		if v == nil || !d.NewValueKnown(fmt.Sprintf("node.%d.identifier", i)) {
+			allKnown = false
+			continue
+		}
+		identifier := v.(map[string]interface{})["identifier"].(string)
+		if identifiers[identifier] {
+			return fmt.Errorf("node identifier %q is used more than once", identifier)
+		}
+		identifiers[identifier] = true
+	}
+
+	edges := make(map[string][]string)
+	for i, v := range nodes {
+		if v == nil || !d.NewValueKnown(fmt.Sprintf("node.%d.identifier", i)) {
+			continue
+		}
+		node := v.(map[string]interface{})
+		identifier := node["identifier"].(string)
+		hasTemplate := node["unified_job_template_id"].(int) != 0 || !d.NewValueKnown(fmt.Sprintf("node.%d.unified_job_template_id", i))
+		hasApproval := len(node["approval"].([]interface{})) > 0
+		if hasTemplate == hasApproval {
+			return fmt.Errorf("node %q: exactly one of unified_job_template_id and approval must be set", identifier)
+		}
+
+		linked := make(map[string]string)
+		for _, edge := range workflowNodeEdges {
+			if !d.NewValueKnown(fmt.Sprintf("node.%d.%s", i, edge)) {
+				continue
+			}
+			for _, c := range node[edge].(*schema.Set).List() {
+				child := c.(string)
+				if !identifiers[child] && allKnown {
+					return fmt.Errorf("node %q: %s refers to the unknown node %q", identifier, edge, child)
+				}
+				if other, ok := linked[child]; ok {
+					return fmt.Errorf("node %q: node %q is listed in both %s and %s", identifier, child, other, edge)
+				}
+				linked[child] = edge
+				edges[identifier] = append(edges[identifier], child)
+			}
+		}
+	}
+
+	if cycle := workflowGraphCycle(edges); cycle != nil {
+		return fmt.Errorf("workflow graph contains the cycle %s", strings.Join(cycle, " -> "))
+	}
+	return nil
+}
+
+// workflowGraphCycle returns the identifiers along a cycle of the graph
+// described by edges, starting and ending with the same node, or nil if the
+// graph has no cycle.
+func workflowGraphCycle(edges map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var path []string
+
+	var visit func(node string) []string
+	visit = func(node string) []string {
+		state[node] = visiting
+		path = append(path, node)
+		children := append([]string(nil), edges[node]...)
+		sort.Strings(children)
+		for _, child := range children {
+			switch state[child] {
+			case visiting:
+				for i, n := range path {
+					if n == child {
+						return append(append([]string(nil), path[i:]...), child)
+					}
+				}
+			case unvisited:
+				if cycle := visit(child); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[node] = done
+		return nil
+	}
+
+	var nodes []string
+	for node := range edges {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		if state[node] == unvisited {
+			if cycle := visit(node); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+func resourceWorkflowJobTemplateGraphCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	workflowID := d.Get("workflow_job_template_id").(int)
+	if err := workflowGraphApply(ctx, d, m, workflowID); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: WorkflowJobTemplateGraph not created",
+			"Fail to create the graph of the workflow job template with ID %v, got %s",
+			workflowID, err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(workflowID))
+	return resourceWorkflowJobTemplateGraphRead(ctx, d, m)
+}
+
+func resourceWorkflowJobTemplateGraphUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Update WorkflowJobTemplateGraph", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := workflowGraphApply(ctx, d, m, id); err != nil {
+		return buildDiagUpdateFail("workflow job template graph", id, err)
+	}
+	return resourceWorkflowJobTemplateGraphRead(ctx, d, m)
+}
+
+func resourceWorkflowJobTemplateGraphRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read WorkflowJobTemplateGraph", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	live, err := workflowGraphLiveNodes(ctx, m, id)
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return buildDiagNotFoundFail("workflow job template graph", id, err)
+	}
+
+	// Keep the order of the nodes in the state, nodes added outside of
+	// Terraform are appended ordered by identifier.
+	position := make(map[string]int)
+	for i, v := range d.Get("node").([]interface{}) {
+		if v != nil {
+			position[v.(map[string]interface{})["identifier"].(string)] = i
+		}
+	}
+	approvals, err := workflowGraphApprovals(ctx, m, live)
+	if err != nil {
+		return buildDiagNotFoundFail("workflow job template graph approvals", id, err)
+	}
+	associations, err := workflowGraphAssociations(ctx, m, live)
+	if err != nil {
+		return buildDiagNotFoundFail("workflow job template graph prompts", id, err)
+	}
+	identifiers := workflowGraphIdentifiers(live)
+	var nodes []map[string]interface{}
+	for _, n := range live {
+		nodes = append(nodes, flattenWorkflowGraphNode(n, identifiers, approvals, associations[workflowGraphInt(n["id"])]))
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i]["identifier"].(string), nodes[j]["identifier"].(string)
+		pa, okA := position[a]
+		pb, okB := position[b]
+		switch {
+		case okA && okB:
+			return pa < pb
+		case okA != okB:
+			return okA
+		}
+		return a < b
+	})
+
+	var result []interface{}
+	for _, n := range nodes {
+		result = append(result, n)
+	}
+	d.Set("workflow_job_template_id", id)
+	d.Set("node", result)
+	return diags
+}
+
+func resourceWorkflowJobTemplateGraphDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Delete WorkflowJobTemplateGraph", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	live, err := workflowGraphLiveNodes(ctx, m, id)
+	if err != nil && !isNotFound(err) {
+		return buildDiagDeleteFail("WorkflowJobTemplateGraph", fmt.Sprintf("WorkflowJobTemplateID %v, got %s ", id, err.Error()))
+	}
+	for _, n := range live {
+		nodeID := workflowGraphInt(n["id"])
+		err := getAPIClient(m).delete(ctx, fmt.Sprintf("/api/v2/workflow_job_template_nodes/%d/", nodeID))
+		if err != nil && !isNotFound(err) {
+			return buildDiagDeleteFail("WorkflowJobTemplateGraph", fmt.Sprintf("WorkflowJobTemplateNodeID %v, got %s ", nodeID, err.Error()))
+		}
+	}
+	d.SetId("")
+	return diags
+}
+
+func workflowGraphLiveNodes(ctx context.Context, m interface{}, workflowID int) ([]map[string]interface{}, error) {
+	return getAPIClient(m).list(ctx, fmt.Sprintf("/api/v2/workflow_job_templates/%d/workflow_nodes/", workflowID), nil)
+}
+
+// workflowGraphIdentifiers maps the IDs of live nodes to their identifiers.
+func workflowGraphIdentifiers(live []map[string]interface{}) map[int]string {
+	identifiers := make(map[int]string)
+	for _, n := range live {
+		identifiers[workflowGraphInt(n["id"])], _ = n["identifier"].(string)
+	}
+	return identifiers
+}
+
+// workflowGraphApply turns the live graph of a workflow job template into
+// the configured one. Nodes which are gone are deleted first, so their
+// identifiers can be reused, then nodes are created or updated and finally
+// edges are unlinked before new ones are linked.
+func workflowGraphApply(ctx context.Context, d *schema.ResourceData, m interface{}, workflowID int) error {
+	client := getAPIClient(m)
+	live, err := workflowGraphLiveNodes(ctx, m, workflowID)
+	if err != nil {
+		return err
+	}
+
+	var wanted []map[string]interface{}
+	wantedIdentifiers := make(map[string]bool)
+	for _, v := range d.Get("node").([]interface{}) {
+		node := v.(map[string]interface{})
+		wanted = append(wanted, node)
+		wantedIdentifiers[node["identifier"].(string)] = true
+	}
+
+	approvals, err := workflowGraphApprovals(ctx, m, live)
+	if err != nil {
+		return err
+	}
+	identifiers := workflowGraphIdentifiers(live)
+	liveNodes := make(map[string]map[string]interface{})
+	for _, n := range live {
+		nodeID := workflowGraphInt(n["id"])
+		if identifier := identifiers[nodeID]; wantedIdentifiers[identifier] {
+			liveNodes[identifier] = n
+			continue
+		}
+		if err := client.delete(ctx, fmt.Sprintf("/api/v2/workflow_job_template_nodes/%d/", nodeID)); err != nil && !isNotFound(err) {
+			return err
+		}
+		delete(identifiers, nodeID)
+	}
+	var kept []map[string]interface{}
+	for _, n := range liveNodes {
+		kept = append(kept, n)
+	}
+	associations, err := workflowGraphAssociations(ctx, m, kept)
+	if err != nil {
+		return err
+	}
+
+	ids := make(map[string]int)
+	for _, node := range wanted {
+		identifier := node["identifier"].(string)
+		payload, err := workflowGraphNodePayload(node)
+		if err != nil {
+			return fmt.Errorf("node %q: %s", identifier, err)
+		}
+		approval := node["approval"].([]interface{})
+		wantedAssociations := workflowNodeAssociationIDs(func(key string) interface{} {
+			return node[key]
+		})
+
+		if n, ok := liveNodes[identifier]; ok {
+			ids[identifier] = workflowGraphInt(n["id"])
+			current := flattenWorkflowGraphNode(n, identifiers, approvals, associations[ids[identifier]])
+			if workflowGraphNodeChanged(node, current) {
+				if len(approval) > 0 {
+					// the approval template is linked by create_approval_template
+					delete(payload, "unified_job_template")
+				}
+				if err := client.patch(ctx, fmt.Sprintf("/api/v2/workflow_job_template_nodes/%d/", ids[identifier]), payload, nil); err != nil {
+					return fmt.Errorf("node %q: %s", identifier, err)
+				}
+			}
+			if len(approval) > 0 && !reflect.DeepEqual(approval, current["approval"]) {
+				if err := workflowApprovalUpdate(ctx, m, ids[identifier], workflowNodeApprovalTemplateID(n), approval[0].(map[string]interface{})); err != nil {
+					return fmt.Errorf("node %q: %s", identifier, err)
+				}
+			}
+			if err := workflowNodeAssociationsUpdate(ctx, m, ids[identifier], associations[ids[identifier]], wantedAssociations); err != nil {
+				return fmt.Errorf("node %q: %s", identifier, err)
+			}
+			continue
+		}
+
+		var created struct {
+			ID int `json:"id"`
+		}
+		if err := client.post(ctx, fmt.Sprintf("/api/v2/workflow_job_templates/%d/workflow_nodes/", workflowID), payload, &created); err != nil {
+			return fmt.Errorf("node %q: %s", identifier, err)
+		}
+		ids[identifier] = created.ID
+		if len(approval) > 0 {
+			if err := workflowApprovalUpdate(ctx, m, created.ID, 0, approval[0].(map[string]interface{})); err != nil {
+				return fmt.Errorf("node %q: %s", identifier, err)
+			}
+		}
+		if err := workflowNodeAssociationsUpdate(ctx, m, created.ID, nil, wantedAssociations); err != nil {
+			return fmt.Errorf("node %q: %s", identifier, err)
+		}
+	}
+
+	type edgeChange struct {
+		path    string
+		payload map[string]interface{}
+	}
+	var unlink, link []edgeChange
+	for _, node := range wanted {
+		identifier := node["identifier"].(string)
+		for _, edge := range workflowNodeEdges {
+			path := fmt.Sprintf("/api/v2/workflow_job_template_nodes/%d/%s/", ids[identifier], edge)
+
+			current := make(map[int]bool)
+			if n, ok := liveNodes[identifier]; ok {
+				children, _ := n[edge].([]interface{})
+				for _, c := range children {
+					if childID := workflowGraphInt(c); identifiers[childID] != "" {
+						current[childID] = true
+					}
+				}
+			}
+			want := make(map[int]bool)
+			for _, c := range node[edge].(*schema.Set).List() {
+				want[ids[c.(string)]] = true
+			}
+
+			for childID := range current {
+				if !want[childID] {
+					unlink = append(unlink, edgeChange{path, map[string]interface{}{
+						"id":           childID,
+						"disassociate": true, // presence of key triggers removal
+					}})
+				}
+			}
+			for childID := range want {
+				if !current[childID] {
+					link = append(link, edgeChange{path, map[string]interface{}{
+						"id": childID,
+					}})
+				}
+			}
+		}
+	}
+	for _, c := range append(unlink, link...) {
+		if err := client.post(ctx, c.path, c.payload, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// workflowGraphNodePayload builds the AWX node of a node block. Prompts
+// which are not set are sent as null, AWX rejects prompts the template does
+// not ask for on launch.
+func workflowGraphNodePayload(node map[string]interface{}) (map[string]interface{}, error) {
+	payload := map[string]interface{}{
+		"identifier":                node["identifier"].(string),
+		"unified_job_template":      optionalID(node["unified_job_template_id"].(int)),
+		"all_parents_must_converge": node["all_parents_must_converge"].(bool),
+		"inventory":                 optionalID(node["inventory_id"].(int)),
+		"extra_data":                map[string]interface{}{},
+		"diff_mode":                 nil,
+		"verbosity":                 nil,
+	}
+	if extraData := node["extra_data"].(string); extraData != "" {
+		var data map[string]interface{}
+		if err := json.Unmarshal([]byte(extraData), &data); err != nil {
+			return nil, fmt.Errorf("extra_data is not a JSON object: %s", err)
+		}
+		payload["extra_data"] = data
+	}
+	for _, key := range []string{"scm_branch", "job_type", "job_tags", "skip_tags", "limit"} {
+		payload[key] = nil
+		if v := node[key].(string); v != "" {
+			payload[key] = v
+		}
+	}
+	if node["diff_mode"].(bool) {
+		payload["diff_mode"] = true
+	}
+	if verbosity := node["verbosity"].(int); verbosity != 0 {
+		payload["verbosity"] = verbosity
+	}
+	for k, v := range workflowNodePromptPayload(func(key string) interface{} { return node[key] }) {
+		payload[k] = v
+	}
+	return payload, nil
+}
+
+// flattenWorkflowGraphNode turns a live AWX node into a node block, edges
+// are translated to the identifiers of the child nodes, approval templates
+// are looked up in approvals and associations are the credentials, labels
+// and instance groups of the node.
+func flattenWorkflowGraphNode(n map[string]interface{}, identifiers map[int]string, approvals map[int]map[string]interface{}, associations map[string][]int) map[string]interface{} {
+	identifier, _ := n["identifier"].(string)
+	node := map[string]interface{}{
+		"identifier":                identifier,
+		"unified_job_template_id":   workflowGraphInt(n["unified_job_template"]),
+		"all_parents_must_converge": n["all_parents_must_converge"] == true,
+		"inventory_id":              workflowGraphInt(n["inventory"]),
+		"extra_data":                "",
+		"diff_mode":                 n["diff_mode"] == true,
+		"verbosity":                 workflowGraphInt(n["verbosity"]),
+	}
+	if data, ok := n["extra_data"].(map[string]interface{}); ok && len(data) > 0 {
+		b, _ := json.Marshal(data)
+		node["extra_data"] = normalizeJsonYaml(string(b))
+	}
+	for _, key := range []string{"scm_branch", "job_type", "job_tags", "skip_tags", "limit"} {
+		v, _ := n[key].(string)
+		node[key] = v
+	}
+	for _, edge := range workflowNodeEdges {
+		var children []interface{}
+		ids, _ := n[edge].([]interface{})
+		for _, c := range ids {
+			if identifier, ok := identifiers[workflowGraphInt(c)]; ok {
+				children = append(children, identifier)
+			}
+		}
+		node[edge] = children
+	}
+	for k, v := range flattenWorkflowNodePrompts(n) {
+		node[k] = v
+	}
+	for _, a := range workflowNodePromptAssociations {
+		ids := []interface{}{}
+		for _, id := range associations[a.key] {
+			ids = append(ids, id)
+		}
+		node[a.key] = ids
+	}
+	node["approval"] = []interface{}{}
+	if templateID := workflowNodeApprovalTemplateID(n); templateID != 0 {
+		node["unified_job_template_id"] = 0
+		node["approval"] = []interface{}{approvals[templateID]}
+	}
+	return node
+}
+
+// workflowGraphApprovals reads the approval templates of the approval nodes
+// of a workflow, keyed by their ID.
+func workflowGraphApprovals(ctx context.Context, m interface{}, live []map[string]interface{}) (map[int]map[string]interface{}, error) {
+	approvals := make(map[int]map[string]interface{})
+	for _, n := range live {
+		templateID := workflowNodeApprovalTemplateID(n)
+		if templateID == 0 {
+			continue
+		}
+		approval, err := getWorkflowApproval(ctx, m, templateID)
+		if err != nil {
+			return nil, err
+		}
+		approvals[templateID] = approval
+	}
+	return approvals, nil
+}
+
+// workflowGraphNodeChanged reports whether the attributes of a configured
+// node differ from the live node, edges are compared separately.
+func workflowGraphNodeChanged(wanted, live map[string]interface{}) bool {
+	for _, key := range workflowGraphScalars {
+		want := wanted[key]
+		if key == "extra_data" {
+			want = normalizeJsonYaml(want)
+		}
+		if want != live[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// workflowGraphInt returns a numeric ID or value of a decoded AWX object, 0
+// if it is null.
+func workflowGraphInt(v interface{}) int {
+	if n, ok := v.(float64); ok {
+		return int(n)
+	}
+	return 0
+}
+
+// workflowGraphAssociations reads the credentials, labels and instance
+// groups of live nodes, keyed by the node ID.
+func workflowGraphAssociations(ctx context.Context, m interface{}, live []map[string]interface{}) (map[int]map[string][]int, error) {
+	associations := make(map[int]map[string][]int)
+	for _, n := range live {
+		nodeID := workflowGraphInt(n["id"])
+		a, err := workflowNodeAssociations(ctx, m, nodeID)
+		if err != nil {
+			return nil, err
+		}
+		associations[nodeID] = a
+	}
+	return associations, nil
+}
@@ -0,0 +1,264 @@
+package awx
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestWorkflowGraphCycle(t *testing.T) {
+	cases := []struct {
+		edges map[string][]string
+		want  []string
+	}{
+		{map[string][]string{}, nil},
+		{map[string][]string{"a": {"b", "c"}, "b": {"d"}, "c": {"d"}}, nil},
+		{map[string][]string{"a": {"a"}}, []string{"a", "a"}},
+		{map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}}, []string{"a", "b", "c", "a"}},
+		{map[string][]string{"a": {"b"}, "b": {"c", "d"}, "d": {"b"}}, []string{"b", "d", "b"}},
+	}
+	for _, c := range cases {
+		if got := workflowGraphCycle(c.edges); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("workflowGraphCycle(%v) = %v, want %v", c.edges, got, c.want)
+		}
+	}
+}
+
+func testWorkflowGraphConfig(nodes string) string {
+	return testJobTemplateConfig("hello_world.yml") + fmt.Sprintf(`
+resource "awx_workflow_job_template" "test" {
+  name            = "test-workflow"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_instance_group" "test" {
+  name = "test"
+}
+
+resource "awx_workflow_job_template_graph" "test" {
+  workflow_job_template_id = awx_workflow_job_template.test.id
+%s
+}
+`, nodes)
+}
+
+func testWorkflowGraphNode(identifier, attributes string) string {
+	return fmt.Sprintf(`
+  node {
+    identifier              = %q
+    unified_job_template_id = awx_job_template.test.id
+%s
+  }
+`, identifier, attributes)
+}
+
+// checkWorkflowGraph compares the nodes of the workflow behind name and
+// their edges, written as "<parent> <edge> <child>", with the fake AWX.
+func (f *fakeAWX) checkWorkflowGraph(name string, identifiers []string, edges ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		nodes := make(map[int]string)
+		for _, id := range f.related["workflow_job_templates/"+rs.Primary.ID+"/workflow_nodes"] {
+			if node, ok := f.collections["workflow_job_template_nodes"][id]; ok {
+				nodes[id] = fakeString(node["identifier"])
+			}
+		}
+		var gotIdentifiers, gotEdges []string
+		for id, identifier := range nodes {
+			gotIdentifiers = append(gotIdentifiers, identifier)
+			for _, edge := range workflowNodeEdges {
+				for _, child := range f.related["workflow_job_template_nodes/"+strconv.Itoa(id)+"/"+edge] {
+					if c, ok := nodes[child]; ok {
+						gotEdges = append(gotEdges, fmt.Sprintf("%s %s %s", identifier, edge, c))
+					}
+				}
+			}
+		}
+		sort.Strings(gotIdentifiers)
+		sort.Strings(gotEdges)
+		sort.Strings(identifiers)
+		sort.Strings(edges)
+		if strings.Join(gotIdentifiers, ",") != strings.Join(identifiers, ",") {
+			return fmt.Errorf("nodes of %s are %v, want %v", name, gotIdentifiers, identifiers)
+		}
+		if strings.Join(gotEdges, ",") != strings.Join(edges, ",") {
+			return fmt.Errorf("edges of %s are %v, want %v", name, gotEdges, edges)
+		}
+		return nil
+	}
+}
+
+func TestResourceWorkflowJobTemplateGraph(t *testing.T) {
+	fake := newFakeAWX(t)
+	var buildID string
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_workflow_job_template", "workflow_job_templates"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testWorkflowGraphConfig(
+					testWorkflowGraphNode("build", `success_nodes = ["test-eu", "test-us"]`) +
+						testWorkflowGraphNode("test-eu", `success_nodes = ["deploy"]`) +
+						testWorkflowGraphNode("test-us", `success_nodes = ["deploy"]`) +
+						testWorkflowGraphNode("deploy", "limit = \"web\"\nforks = 5\ninstance_group_ids = [awx_instance_group.test.id]"),
+				)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template_graph.test", "node.#", "4"),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_graph.test", "node.0.identifier", "build"),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_graph.test", "node.3.limit", "web"),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_graph.test", "node.3.forks", "5"),
+					resource.TestCheckResourceAttrPair("awx_workflow_job_template_graph.test", "node.3.instance_group_ids.0", "awx_instance_group.test", "id"),
+					fake.checkWorkflowGraph("awx_workflow_job_template_graph.test",
+						[]string{"build", "test-eu", "test-us", "deploy"},
+						"build success_nodes test-eu",
+						"build success_nodes test-us",
+						"test-eu success_nodes deploy",
+						"test-us success_nodes deploy",
+					),
+					testCheckWorkflowGraphNodeID(fake, "build", &buildID),
+				),
+			},
+			{
+				// test-us is replaced by test-ap, deploy runs after test-eu
+				// regardless of its result and rollback is added.
+				Config: fake.config(testWorkflowGraphConfig(
+					testWorkflowGraphNode("build", `success_nodes = ["test-eu", "test-ap"]`) +
+						testWorkflowGraphNode("deploy", `failure_nodes = ["rollback"]`) +
+						testWorkflowGraphNode("rollback", "") +
+						testWorkflowGraphNode("test-ap", `success_nodes = ["deploy"]`) +
+						testWorkflowGraphNode("test-eu", `always_nodes = ["deploy"]`),
+				)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template_graph.test", "node.#", "5"),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_graph.test", "node.1.identifier", "deploy"),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_graph.test", "node.1.limit", ""),
+					fake.checkWorkflowGraph("awx_workflow_job_template_graph.test",
+						[]string{"build", "test-eu", "test-ap", "deploy", "rollback"},
+						"build success_nodes test-eu",
+						"build success_nodes test-ap",
+						"test-eu always_nodes deploy",
+						"test-ap success_nodes deploy",
+						"deploy failure_nodes rollback",
+					),
+					testCheckWorkflowGraphNodeID(fake, "build", &buildID),
+				),
+			},
+			{
+				Config: fake.config(testWorkflowGraphConfig(
+					testWorkflowGraphNode("build", `success_nodes = ["deploy"]`) +
+						testWorkflowGraphNode("deploy", `failure_nodes = ["build"]`),
+				)),
+				ExpectError: regexp.MustCompile(`cycle build -> deploy -> build`),
+			},
+			{
+				Config: fake.config(testWorkflowGraphConfig(
+					testWorkflowGraphNode("build", `success_nodes = ["deploy"]`),
+				)),
+				ExpectError: regexp.MustCompile(`refers to the unknown node "deploy"`),
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_graph.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_graph.test",
+				ImportState:       true,
+				ImportStateId:     "test-org/test-workflow",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testCheckWorkflowGraphNodeID checks that the node with the identifier
+// keeps its ID, that is it was updated in place and not recreated.
+func testCheckWorkflowGraphNodeID(f *fakeAWX, identifier string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for nodeID, node := range f.collections["workflow_job_template_nodes"] {
+			if fakeString(node["identifier"]) != identifier {
+				continue
+			}
+			if *id != "" && *id != strconv.Itoa(nodeID) {
+				return fmt.Errorf("node %s was recreated, ID %d, was %s", identifier, nodeID, *id)
+			}
+			*id = strconv.Itoa(nodeID)
+			return nil
+		}
+		return fmt.Errorf("node %s not found", identifier)
+	}
+}
+
+func TestResourceWorkflowJobTemplateGraphApproval(t *testing.T) {
+	fake := newFakeAWX(t)
+	config := func(timeout int) string {
+		return testWorkflowGraphConfig(
+			testWorkflowGraphNode("build", `success_nodes = ["change-approval"]`) +
+				fmt.Sprintf(`
+  node {
+    identifier    = "change-approval"
+    success_nodes = ["deploy"]
+
+    approval {
+      name    = "change-approval"
+      timeout = %d
+    }
+  }
+`, timeout) +
+				testWorkflowGraphNode("deploy", ""),
+		)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_workflow_job_template", "workflow_job_templates"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(config(3600)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template_graph.test", "node.1.approval.0.timeout", "3600"),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_graph.test", "node.1.unified_job_template_id", "0"),
+					fake.checkWorkflowGraph("awx_workflow_job_template_graph.test",
+						[]string{"build", "change-approval", "deploy"},
+						"build success_nodes change-approval",
+						"change-approval success_nodes deploy",
+					),
+				),
+			},
+			{
+				Config: fake.config(config(600)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template_graph.test", "node.1.approval.0.timeout", "600"),
+					fake.checkApprovalTemplates(1),
+				),
+			},
+			{
+				Config: fake.config(testWorkflowGraphConfig(`
+  node {
+    identifier = "build"
+  }
+`)),
+				ExpectError: regexp.MustCompile(`exactly one of unified_job_template_id and approval must be set`),
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_graph.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
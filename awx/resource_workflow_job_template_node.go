@@ -1,19 +1,59 @@
 /*
-*TBD*
+Child nodes can be linked inline with success_nodes, failure_nodes and
+always_nodes, which allows any graph including nodes several parents converge
+on. Do not combine these sets with awx_workflow_job_template_node_success,
+_failure or _always resources below the same parent, the sets list all child
+nodes of a node. Leaving a set out keeps the edges of that kind as they are,
+unless authoritative_edges is set: the sets then list every child, and a set
+left out or empty unlinks all children of that kind.
+
+Nodes run a unified job template or, with an approval block, pause the
+workflow until the approval is approved or denied in AWX.
 
 # Example Usage
 
 ```hcl
-resource "random_uuid" "workflow_node_base_uuid" {}
 
-	resource "awx_workflow_job_template_node" "default" {
+	resource "awx_workflow_job_template_node" "deploy" {
+	  workflow_job_template_id = awx_workflow_job_template.default.id
+	  unified_job_template_id  = awx_job_template.deploy.id
+	  identifier               = "deploy"
+	  success_nodes            = [awx_workflow_job_template_node.smoke_test.id]
+	  failure_nodes            = [awx_workflow_job_template_node.rollback.id]
+	}
+
+	resource "awx_workflow_job_template_node" "smoke_test" {
+	  workflow_job_template_id = awx_workflow_job_template.default.id
+	  unified_job_template_id  = awx_job_template.smoke_test.id
+	  identifier               = "smoke-test"
+	  always_nodes             = [awx_workflow_job_template_node.cleanup.id]
+	}
+
+	resource "awx_workflow_job_template_node" "rollback" {
 	  workflow_job_template_id = awx_workflow_job_template.default.id
-	  unified_job_template_id  = awx_job_template.baseconfig.id
-	  inventory_id             = awx_inventory.default.id
-	  identifier               = random_uuid.workflow_node_base_uuid.result
+	  unified_job_template_id  = awx_job_template.rollback.id
+	  identifier               = "rollback"
+	  always_nodes             = [awx_workflow_job_template_node.cleanup.id]
+	}
+
+	resource "awx_workflow_job_template_node" "cleanup" {
+	  workflow_job_template_id  = awx_workflow_job_template.default.id
+	  unified_job_template_id   = awx_job_template.cleanup.id
+	  identifier                = "cleanup"
+	  all_parents_must_converge = false
 	}
 
 ```
+
+# Import
+
+Workflow job template nodes can be imported using the node ID or the name
+path `<organization>/<workflow>/<identifier>`.
+
+```sh
+terraform import awx_workflow_job_template_node.default 42
+terraform import awx_workflow_job_template_node.default Default/release/deploy
+```
 */
 package awx
 
@@ -34,8 +74,9 @@ func resourceWorkflowJobTemplateNode() *schema.Resource {
 		ReadContext:   resourceWorkflowJobTemplateNodeRead,
 		UpdateContext: resourceWorkflowJobTemplateNodeUpdate,
 		DeleteContext: resourceWorkflowJobTemplateNodeDelete,
+		CustomizeDiff: customizeDiffWorkflowNodeEdges,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withWorkflowNodePrompts(map[string]*schema.Schema{
 
 			"extra_data": {
 				Type:        schema.TypeString,
@@ -85,31 +126,16 @@ func resourceWorkflowJobTemplateNode() *schema.Resource {
 				Required: true,
 			},
 			"unified_job_template_id": {
-				Type:     schema.TypeInt,
-				Required: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{"unified_job_template_id", "approval"},
+				Description:  "Numeric ID of the job template, project, inventory source or workflow run by the node, required unless approval is set",
 			},
-			//"success_nodes": &schema.Schema{
-			//	Type: schema.TypeList,
-			//	Elem: &schema.Schema{
-			//		Type: schema.TypeInt,
-			//	},
-			//	Optional: true,
-			//},
-			//"failure_nodes": &schema.Schema{
-			//	Type: schema.TypeList,
-			//	Elem: &schema.Schema{
-			//		Type: schema.TypeInt,
-			//	},
-			//	Optional: true,
-			//},
-			//"always_nodes": &schema.Schema{
-			//	Type: schema.TypeList,
-			//	Elem: &schema.Schema{
-			//		Type: schema.TypeInt,
-			//	},
-			//	Optional: true,
-			//},
-
+			"approval":            workflowApprovalSchema,
+			"success_nodes":       workflowNodeEdgeSchema("success"),
+			"failure_nodes":       workflowNodeEdgeSchema("failure"),
+			"always_nodes":        workflowNodeEdgeSchema("always"),
+			"authoritative_edges": workflowNodeAuthoritativeEdgesSchema,
 			"all_parents_must_converge": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -119,11 +145,11 @@ func resourceWorkflowJobTemplateNode() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+		}),
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathWorkflowJobTemplateNode),
 		},
-		//Importer: &schema.ResourceImporter{
-		//	State: schema.ImportStatePassthrough,
-		//},
-		//
+
 		//Timeouts: &schema.ResourceTimeout{
 		//	Create: schema.DefaultTimeout(1 * time.Minute),
 		//	Update: schema.DefaultTimeout(1 * time.Minute),
@@ -137,25 +163,25 @@ func resourceWorkflowJobTemplateNodeCreate(ctx context.Context, d *schema.Resour
 	client := m.(*awx.AWX)
 	awxService := client.WorkflowJobTemplateNodeService
 
-	result, err := awxService.CreateWorkflowJobTemplateNode(map[string]interface{}{
-		"extra_data":            d.Get("extra_data").(string),
-		"inventory":             d.Get("inventory_id").(int),
-		"scm_branch":            d.Get("scm_branch").(string),
-		"skip_tags":             d.Get("skip_tags").(string),
-		"job_type":              d.Get("job_type").(string),
-		"job_tags":              d.Get("job_tags").(string),
-		"limit":                 d.Get("limit").(string),
-		"diff_mode":             d.Get("diff_mode").(bool),
-		"verbosity":             d.Get("verbosity").(int),
-		"workflow_job_template": d.Get("workflow_job_template_id").(int),
-		"unified_job_template":  d.Get("unified_job_template_id").(int),
-		//"failure_nodes":         d.Get("failure_nodes").([]interface{}),
-		//"success_nodes":         d.Get("success_nodes").([]interface{}),
-		//"always_nodes":          d.Get("always_nodes").([]interface{}),
-
+	payload := map[string]interface{}{
+		"extra_data":                d.Get("extra_data").(string),
+		"inventory":                 d.Get("inventory_id").(int),
+		"scm_branch":                d.Get("scm_branch").(string),
+		"skip_tags":                 d.Get("skip_tags").(string),
+		"job_type":                  d.Get("job_type").(string),
+		"job_tags":                  d.Get("job_tags").(string),
+		"limit":                     d.Get("limit").(string),
+		"diff_mode":                 d.Get("diff_mode").(bool),
+		"verbosity":                 d.Get("verbosity").(int),
+		"workflow_job_template":     d.Get("workflow_job_template_id").(int),
+		"unified_job_template":      optionalID(d.Get("unified_job_template_id").(int)),
 		"all_parents_must_converge": d.Get("all_parents_must_converge").(bool),
 		"identifier":                d.Get("identifier").(string),
-	}, map[string]string{})
+	}
+	for k, v := range workflowNodePromptPayload(d.Get) {
+		payload[k] = v
+	}
+	result, err := awxService.CreateWorkflowJobTemplateNode(payload, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Template %v", err)
 		diags = append(diags, diag.Diagnostic{
@@ -167,6 +193,27 @@ func resourceWorkflowJobTemplateNodeCreate(ctx context.Context, d *schema.Resour
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
+	if err := workflowNodeApprovalUpdate(ctx, d, m, result.ID); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: WorkflowJobTemplateNode approval not created",
+			"Fail to create the approval of WorkflowJobTemplateNode with ID %v, got %s",
+			result.ID, err.Error(),
+		)
+	}
+	if err := workflowNodePromptsUpdate(ctx, d, m, result.ID); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: WorkflowJobTemplateNode prompts not set",
+			"Fail to set the credentials, labels and instance groups of WorkflowJobTemplateNode with ID %v, got %s",
+			result.ID, err.Error(),
+		)
+	}
+	if err := workflowNodeEdgesUpdate(ctx, d, m, result.ID); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: WorkflowJobTemplateNode edges not linked",
+			"Fail to link the child nodes of WorkflowJobTemplateNode with ID %v, got %s",
+			result.ID, err.Error(),
+		)
+	}
 	return resourceWorkflowJobTemplateNodeRead(ctx, d, m)
 }
 
@@ -185,32 +232,50 @@ func resourceWorkflowJobTemplateNodeUpdate(ctx context.Context, d *schema.Resour
 		return buildDiagNotFoundFail("workflow job template node", id, err)
 	}
 
-	_, err = awxService.UpdateWorkflowJobTemplateNode(id, map[string]interface{}{
-		"extra_data":            d.Get("extra_data").(string),
-		"inventory":             d.Get("inventory_id").(int),
-		"scm_branch":            d.Get("scm_branch").(string),
-		"skip_tags":             d.Get("skip_tags").(string),
-		"job_type":              d.Get("job_type").(string),
-		"job_tags":              d.Get("job_tags").(string),
-		"limit":                 d.Get("limit").(string),
-		"diff_mode":             d.Get("diff_mode").(bool),
-		"verbosity":             d.Get("verbosity").(int),
-		"workflow_job_template": d.Get("workflow_job_template_id").(int),
-		"unified_job_template":  d.Get("unified_job_template_id").(int),
-		//"failure_nodes":             d.Get("failure_nodes").([]interface{}),
-		//"success_nodes":             d.Get("success_nodes").([]interface{}),
-		//"always_nodes":              d.Get("always_nodes").([]interface{}),
+	payload := map[string]interface{}{
+		"extra_data":                d.Get("extra_data").(string),
+		"inventory":                 d.Get("inventory_id").(int),
+		"scm_branch":                d.Get("scm_branch").(string),
+		"skip_tags":                 d.Get("skip_tags").(string),
+		"job_type":                  d.Get("job_type").(string),
+		"job_tags":                  d.Get("job_tags").(string),
+		"limit":                     d.Get("limit").(string),
+		"diff_mode":                 d.Get("diff_mode").(bool),
+		"verbosity":                 d.Get("verbosity").(int),
+		"unified_job_template":      optionalID(d.Get("unified_job_template_id").(int)),
 		"all_parents_must_converge": d.Get("all_parents_must_converge").(bool),
 		"identifier":                d.Get("identifier").(string),
-	}, map[string]string{})
+	}
+	// the success, failure and always step resources share this update
+	// but have no workflow_job_template_id
+	if workflowID, ok := d.Get("workflow_job_template_id").(int); ok {
+		payload["workflow_job_template"] = workflowID
+	}
+	for k, v := range workflowNodePromptPayload(d.Get) {
+		payload[k] = v
+	}
+	if len(d.Get("approval").([]interface{})) > 0 {
+		// the approval template is linked by create_approval_template
+		delete(payload, "unified_job_template")
+	}
+	_, err = awxService.UpdateWorkflowJobTemplateNode(id, payload, map[string]string{})
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to update WorkflowJobTemplateNode",
-			Detail:   fmt.Sprintf("WorkflowJobTemplateNode with name %s in the project id %d faild to update %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
+			Detail:   fmt.Sprintf("WorkflowJobTemplateNode with ID %d faild to update %s", id, err.Error()),
 		})
 		return diags
 	}
+	if err := workflowNodeApprovalUpdate(ctx, d, m, id); err != nil {
+		return buildDiagUpdateFail("workflow job template node approval", id, err)
+	}
+	if err := workflowNodePromptsUpdate(ctx, d, m, id); err != nil {
+		return buildDiagUpdateFail("workflow job template node prompts", id, err)
+	}
+	if err := workflowNodeEdgesUpdate(ctx, d, m, id); err != nil {
+		return buildDiagUpdateFail("workflow job template node edges", id, err)
+	}
 
 	return resourceWorkflowJobTemplateNodeRead(ctx, d, m)
 }
@@ -230,6 +295,21 @@ func resourceWorkflowJobTemplateNodeRead(ctx context.Context, d *schema.Resource
 
 	}
 	d = setWorkflowJobTemplateNodeResourceData(d, res)
+
+	// goawx does not expose approvals and the newer prompts of nodes
+	var node map[string]interface{}
+	if err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/workflow_job_template_nodes/%d/", id), nil, &node); err != nil {
+		return buildDiagNotFoundFail("workflow job template node", id, err)
+	}
+	if err := setWorkflowApprovalResourceData(ctx, d, m, node); err != nil {
+		return buildDiagNotFoundFail("workflow job template node approval", id, err)
+	}
+	if err := setWorkflowNodePromptsResourceData(ctx, d, m, node); err != nil {
+		return buildDiagNotFoundFail("workflow job template node prompts", id, err)
+	}
+	if err := setWorkflowNodeEdgesResourceData(ctx, d, m, id); err != nil {
+		return buildDiagNotFoundFail("workflow job template node edges", id, err)
+	}
 	return nil
 }
 
@@ -254,7 +334,7 @@ func resourceWorkflowJobTemplateNodeDelete(ctx context.Context, d *schema.Resour
 func setWorkflowJobTemplateNodeResourceData(d *schema.ResourceData, r *awx.WorkflowJobTemplateNode) *schema.ResourceData {
 
 	d.Set("extra_data", normalizeJsonYaml(r.ExtraData))
-	d.Set("inventory_id", strconv.Itoa(r.Inventory))
+	d.Set("inventory_id", r.Inventory)
 	d.Set("scm_branch", r.ScmBranch)
 	d.Set("job_type", r.JobType)
 	d.Set("job_tags", r.JobTags)
@@ -262,12 +342,8 @@ func setWorkflowJobTemplateNodeResourceData(d *schema.ResourceData, r *awx.Workf
 	d.Set("limit", r.Limit)
 	d.Set("diff_mode", r.DiffMode)
 	d.Set("verbosity", r.Verbosity)
-	//d.Set("failure_nodes", r.FailureNodes)
-	//d.Set("success_nodes", r.SuccessNodes)
-	//d.Set("always_nodes", r.AlwaysNodes)
-
-	d.Set("workflow_job_template_id", strconv.Itoa(r.WorkflowJobTemplate))
-	d.Set("unified_job_template_id", strconv.Itoa(r.UnifiedJobTemplate))
+	d.Set("workflow_job_template_id", r.WorkflowJobTemplate)
+	d.Set("unified_job_template_id", r.UnifiedJobTemplate)
 	d.Set("all_parents_must_converge", r.AllParentsMustConverge)
 	d.Set("identifier", r.Identifier)
 
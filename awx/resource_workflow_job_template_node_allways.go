@@ -14,6 +14,17 @@ resource "random_uuid" "workflow_node_k3s_uuid" {}
 	}
 
 ```
+
+# Import
+
+Always nodes can be imported using the parent node and the node separated by a
+colon, each given by its ID or its name path
+`<organization>/<workflow>/<identifier>`.
+
+```sh
+terraform import awx_workflow_job_template_node_allways.k3s 42:43
+terraform import awx_workflow_job_template_node_allways.k3s Default/release/deploy:Default/release/k3s
+```
 */
 package awx
 
@@ -31,7 +42,11 @@ func resourceWorkflowJobTemplateNodeAllways() *schema.Resource {
 		ReadContext:   resourceWorkflowJobTemplateNodeRead,
 		UpdateContext: resourceWorkflowJobTemplateNodeUpdate,
 		DeleteContext: resourceWorkflowJobTemplateNodeDelete,
+		CustomizeDiff: customizeDiffWorkflowNodeEdges,
 		Schema:        workflowJobNodeSchema,
+		Importer: &schema.ResourceImporter{
+			State: importNodeForWorkflowJob,
+		},
 	}
 }
 func resourceWorkflowJobTemplateNodeAllwaysCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
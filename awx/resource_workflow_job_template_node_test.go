@@ -0,0 +1,358 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testWorkflowNodeConfig builds a workflow with the nodes a, b, c and d whose
+// success_nodes are given by edges.
+func testWorkflowNodeConfig(edges map[string]string) string {
+	cfg := testJobTemplateConfig("hello_world.yml") + `
+resource "awx_workflow_job_template" "test" {
+  name            = "test-workflow"
+  organization_id = awx_organization.test.id
+}
+`
+	for _, node := range []string{"a", "b", "c", "d"} {
+		cfg += fmt.Sprintf(`
+resource "awx_workflow_job_template_node" "%s" {
+  workflow_job_template_id  = awx_workflow_job_template.test.id
+  unified_job_template_id   = awx_job_template.test.id
+  identifier                = "%s"
+  all_parents_must_converge = true
+%s
+}
+`, node, node, edges[node])
+	}
+	return cfg
+}
+
+func TestResourceWorkflowJobTemplateNodeEdges(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_workflow_job_template_node", "workflow_job_template_nodes"),
+		Steps: []resource.TestStep{
+			{
+				// a runs b and c, both converge on d.
+				Config: fake.config(testWorkflowNodeConfig(map[string]string{
+					"a": "  success_nodes = [awx_workflow_job_template_node.b.id, awx_workflow_job_template_node.c.id]",
+					"b": "  success_nodes = [awx_workflow_job_template_node.d.id]",
+					"c": "  failure_nodes = [awx_workflow_job_template_node.d.id]",
+				})),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.a", "success_nodes.#", "2"),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.d", "success_nodes.#", "0"),
+					fake.checkRelated("awx_workflow_job_template_node.a", "workflow_job_template_nodes", "success_nodes", "awx_workflow_job_template_node.b"),
+					fake.checkRelated("awx_workflow_job_template_node.a", "workflow_job_template_nodes", "success_nodes", "awx_workflow_job_template_node.c"),
+					fake.checkRelated("awx_workflow_job_template_node.b", "workflow_job_template_nodes", "success_nodes", "awx_workflow_job_template_node.d"),
+					fake.checkRelated("awx_workflow_job_template_node.c", "workflow_job_template_nodes", "failure_nodes", "awx_workflow_job_template_node.d"),
+				),
+			},
+			{
+				// c moves from a to b and runs d regardless of its result.
+				Config: fake.config(testWorkflowNodeConfig(map[string]string{
+					"a": "  success_nodes = [awx_workflow_job_template_node.b.id]",
+					"b": "  success_nodes = [awx_workflow_job_template_node.c.id, awx_workflow_job_template_node.d.id]",
+					"c": "  always_nodes  = [awx_workflow_job_template_node.d.id]",
+				})),
+				Check: resource.ComposeTestCheckFunc(
+					fake.checkNotRelated("awx_workflow_job_template_node.a", "workflow_job_template_nodes", "success_nodes", "awx_workflow_job_template_node.c"),
+					fake.checkRelated("awx_workflow_job_template_node.b", "workflow_job_template_nodes", "success_nodes", "awx_workflow_job_template_node.c"),
+					fake.checkNotRelated("awx_workflow_job_template_node.c", "workflow_job_template_nodes", "failure_nodes", "awx_workflow_job_template_node.d"),
+					fake.checkRelated("awx_workflow_job_template_node.c", "workflow_job_template_nodes", "always_nodes", "awx_workflow_job_template_node.d"),
+				),
+			},
+			{
+				Config: fake.config(testWorkflowNodeConfig(map[string]string{
+					"a": "  success_nodes = [awx_workflow_job_template_node.b.id]\n  failure_nodes = [awx_workflow_job_template_node.b.id]",
+				})),
+				ExpectError: regexp.MustCompile("listed in both success_nodes and failure_nodes"),
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_node.b",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_node.b",
+				ImportState:       true,
+				ImportStateId:     "test-org/test-workflow/b",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceWorkflowJobTemplateNodeAuthoritativeEdges(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_workflow_job_template_node", "workflow_job_template_nodes"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testWorkflowNodeConfig(map[string]string{
+					"a": "  authoritative_edges = true\n  success_nodes = [awx_workflow_job_template_node.b.id]\n  always_nodes = [awx_workflow_job_template_node.c.id]",
+				})),
+				Check: resource.ComposeTestCheckFunc(
+					fake.checkRelated("awx_workflow_job_template_node.a", "workflow_job_template_nodes", "success_nodes", "awx_workflow_job_template_node.b"),
+					fake.checkRelated("awx_workflow_job_template_node.a", "workflow_job_template_nodes", "always_nodes", "awx_workflow_job_template_node.c"),
+				),
+			},
+			{
+				// The last edges of a kind are unlinked by an empty set or
+				// by leaving the set out.
+				Config: fake.config(testWorkflowNodeConfig(map[string]string{
+					"a": "  authoritative_edges = true\n  success_nodes = []",
+				})),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.a", "success_nodes.#", "0"),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.a", "always_nodes.#", "0"),
+					fake.checkNotRelated("awx_workflow_job_template_node.a", "workflow_job_template_nodes", "success_nodes", "awx_workflow_job_template_node.b"),
+					fake.checkNotRelated("awx_workflow_job_template_node.a", "workflow_job_template_nodes", "always_nodes", "awx_workflow_job_template_node.c"),
+				),
+			},
+		},
+	})
+}
+
+// checkApprovalTemplates checks the number of approval templates in the fake
+// AWX, approvals are expected to be updated in place.
+func (f *fakeAWX) checkApprovalTemplates(want int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if got := len(f.collections["workflow_approval_templates"]); got != want {
+			return fmt.Errorf("%d approval templates exist, want %d", got, want)
+		}
+		return nil
+	}
+}
+
+func testWorkflowNodeApprovalConfig(timeout int) string {
+	return testJobTemplateConfig("hello_world.yml") + fmt.Sprintf(`
+resource "awx_workflow_job_template" "test" {
+  name            = "test-workflow"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_workflow_job_template_node" "gate" {
+  workflow_job_template_id = awx_workflow_job_template.test.id
+  identifier               = "gate"
+  success_nodes            = [awx_workflow_job_template_node.deploy.id]
+
+  approval {
+    name        = "change-gate"
+    description = "Approved change request"
+    timeout     = %d
+  }
+}
+
+resource "awx_workflow_job_template_node" "deploy" {
+  workflow_job_template_id = awx_workflow_job_template.test.id
+  unified_job_template_id  = awx_job_template.test.id
+  identifier               = "deploy"
+}
+`, timeout)
+}
+
+func TestResourceWorkflowJobTemplateNodeApproval(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_workflow_job_template_node", "workflow_job_template_nodes"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testWorkflowNodeApprovalConfig(3600)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.gate", "approval.0.name", "change-gate"),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.gate", "approval.0.timeout", "3600"),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.gate", "unified_job_template_id", "0"),
+					fake.checkRelated("awx_workflow_job_template_node.gate", "workflow_job_template_nodes", "success_nodes", "awx_workflow_job_template_node.deploy"),
+					fake.checkApprovalTemplates(1),
+				),
+			},
+			{
+				Config: fake.config(testWorkflowNodeApprovalConfig(0)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.gate", "approval.0.timeout", "0"),
+					fake.checkApprovalTemplates(1),
+				),
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_node.gate",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testWorkflowNodePromptsConfig(prompts string) string {
+	return testJobTemplateConfig("hello_world.yml") + fmt.Sprintf(`
+resource "awx_credential" "test" {
+  name               = "test-credential"
+  organization_id    = awx_organization.test.id
+  credential_type_id = 1
+  inputs = jsonencode({
+    username = "alice"
+  })
+}
+
+resource "awx_label" "nightly" {
+  name            = "nightly"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_label" "canary" {
+  name            = "canary"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_instance_group" "batch" {
+  name = "batch"
+}
+
+resource "awx_instance_group" "fallback" {
+  name = "fallback"
+}
+
+resource "awx_execution_environment" "test" {
+  name  = "test-ee"
+  image = "quay.io/ansible/awx-ee:latest"
+}
+
+resource "awx_workflow_job_template" "test" {
+  name            = "test-workflow"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_workflow_job_template_node" "test" {
+  workflow_job_template_id = awx_workflow_job_template.test.id
+  unified_job_template_id  = awx_job_template.test.id
+  identifier               = "test"
+%s
+}
+
+resource "awx_workflow_job_template_node_success" "test" {
+  workflow_job_template_node_id = awx_workflow_job_template_node.test.id
+  unified_job_template_id       = awx_job_template.test.id
+  identifier                    = "test-success"
+  limit                         = "db"
+  diff_mode                     = true
+}
+`, prompts)
+}
+
+func TestResourceWorkflowJobTemplateNodePrompts(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_workflow_job_template_node", "workflow_job_template_nodes"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testWorkflowNodePromptsConfig(`
+  limit                    = "web"
+  diff_mode                = true
+  forks                    = 10
+  timeout                  = 600
+  job_slice_count          = 2
+  execution_environment_id = awx_execution_environment.test.id
+  credential_ids           = [awx_credential.test.id]
+  label_ids                = [awx_label.nightly.id, awx_label.canary.id]
+  instance_group_ids       = [awx_instance_group.batch.id, awx_instance_group.fallback.id]
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.test", "forks", "10"),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.test", "label_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("awx_workflow_job_template_node.test", "instance_group_ids.0", "awx_instance_group.batch", "id"),
+					fake.checkField("awx_workflow_job_template_node.test", "workflow_job_template_nodes", "timeout", float64(600)),
+					fake.checkField("awx_workflow_job_template_node.test", "workflow_job_template_nodes", "job_slice_count", float64(2)),
+					fake.checkRelated("awx_workflow_job_template_node.test", "workflow_job_template_nodes", "credentials", "awx_credential.test"),
+					fake.checkRelated("awx_workflow_job_template_node.test", "workflow_job_template_nodes", "labels", "awx_label.canary"),
+					fake.checkField("awx_workflow_job_template_node_success.test", "workflow_job_template_nodes", "limit", "db"),
+					fake.checkField("awx_workflow_job_template_node_success.test", "workflow_job_template_nodes", "diff_mode", true),
+				),
+			},
+			{
+				Config: fake.config(testWorkflowNodePromptsConfig(`
+  limit              = "web"
+  label_ids          = [awx_label.nightly.id]
+  instance_group_ids = [awx_instance_group.fallback.id, awx_instance_group.batch.id]
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.test", "forks", "0"),
+					resource.TestCheckResourceAttr("awx_workflow_job_template_node.test", "credential_ids.#", "0"),
+					resource.TestCheckResourceAttrPair("awx_workflow_job_template_node.test", "instance_group_ids.0", "awx_instance_group.fallback", "id"),
+					fake.checkField("awx_workflow_job_template_node.test", "workflow_job_template_nodes", "execution_environment", nil),
+					fake.checkNotRelated("awx_workflow_job_template_node.test", "workflow_job_template_nodes", "credentials", "awx_credential.test"),
+					fake.checkNotRelated("awx_workflow_job_template_node.test", "workflow_job_template_nodes", "labels", "awx_label.canary"),
+				),
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_node.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testWorkflowNodeStepConfig(attributes string) string {
+	return testJobTemplateConfig("hello_world.yml") + fmt.Sprintf(`
+resource "awx_workflow_job_template" "test" {
+  name            = "test-workflow"
+  organization_id = awx_organization.test.id
+}
+
+resource "awx_workflow_job_template_node" "test" {
+  workflow_job_template_id = awx_workflow_job_template.test.id
+  unified_job_template_id  = awx_job_template.test.id
+  identifier               = "test"
+}
+
+resource "awx_workflow_job_template_node_failure" "test" {
+  workflow_job_template_node_id = awx_workflow_job_template_node.test.id
+  unified_job_template_id       = awx_job_template.test.id
+  identifier                    = "test-failure"
+%s
+}
+`, attributes)
+}
+
+func TestResourceWorkflowJobTemplateNodeStepUpdate(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_workflow_job_template_node_failure", "workflow_job_template_nodes"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testWorkflowNodeStepConfig(`limit = "web"`)),
+				Check:  fake.checkField("awx_workflow_job_template_node_failure.test", "workflow_job_template_nodes", "limit", "web"),
+			},
+			{
+				// The step resources are updated in place.
+				Config: fake.config(testWorkflowNodeStepConfig(`
+  limit = "db"
+  forks = 5
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					fake.checkField("awx_workflow_job_template_node_failure.test", "workflow_job_template_nodes", "limit", "db"),
+					fake.checkField("awx_workflow_job_template_node_failure.test", "workflow_job_template_nodes", "forks", float64(5)),
+					fake.checkRelated("awx_workflow_job_template_node.test", "workflow_job_template_nodes", "failure_nodes", "awx_workflow_job_template_node_failure.test"),
+				),
+			},
+			{
+				ResourceName:      "awx_workflow_job_template_node_failure.test",
+				ImportState:       true,
+				ImportStateId:     "test-org/test-workflow/test:test-org/test-workflow/test-failure",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testWorkflowJobTemplateConfig(survey string) string {
+	return fmt.Sprintf(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_workflow_job_template" "test" {
+  name            = "test-workflow"
+  organization_id = awx_organization.test.id
+  survey_enabled  = true
+%s
+}
+`, survey)
+}
+
+func TestResourceWorkflowJobTemplate(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_workflow_job_template", "workflow_job_templates"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testWorkflowJobTemplateConfig("")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template.test", "survey.#", "0"),
+					fake.checkField("awx_workflow_job_template.test", "workflow_job_templates", "survey_enabled", true),
+				),
+			},
+			{
+				Config: fake.config(testWorkflowJobTemplateConfig(`
+  survey {
+    question {
+      question_name = "Release"
+      variable      = "release"
+      type          = "text"
+      required      = true
+    }
+
+    question {
+      question_name = "Regions"
+      variable      = "regions"
+      type          = "multiselect"
+      choices       = ["eu", "us", "ap"]
+      default       = "eu\nus"
+    }
+  }
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_workflow_job_template.test", "survey.0.question.1.default", "eu\nus"),
+					fake.checkSurvey("awx_workflow_job_template.test", "workflow_job_templates", "release", "regions"),
+				),
+			},
+			{
+				ResourceName:      "awx_workflow_job_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
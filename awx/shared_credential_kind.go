@@ -0,0 +1,248 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	validateCredentialPrivateKey = validation.StringMatch(
+		regexp.MustCompile(`^\s*-----BEGIN [A-Z0-9 ]*PRIVATE KEY-----`),
+		"must be a PEM encoded private key",
+	)
+	validateCredentialCertificate = validation.StringMatch(
+		regexp.MustCompile(`^\s*-----BEGIN CERTIFICATE-----`),
+		"must be a PEM encoded certificate",
+	)
+)
+
+// awxCredential is the subset of the AWX credential object managed by the
+// typed credential resources.
+type awxCredential struct {
+	ID             int                    `json:"id"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description"`
+	Organization   int                    `json:"organization"`
+	CredentialType int                    `json:"credential_type"`
+	Inputs         map[string]interface{} `json:"inputs"`
+}
+
+// credentialInput is an input of a managed credential type exposed as an
+// attribute of a typed credential resource.
+type credentialInput struct {
+	attribute     string
+	id            string // input ID in AWX, the attribute name if empty
+	description   string
+	boolean       bool
+	required      bool
+	secret        bool
+	defaultValue  interface{}
+	validate      schema.SchemaValidateFunc
+	requiredWith  []string
+	conflictsWith []string
+}
+
+func (i credentialInput) inputID() string {
+	if i.id == "" {
+		return i.attribute
+	}
+	return i.id
+}
+
+func (i credentialInput) schema() *schema.Schema {
+	s := &schema.Schema{
+		Type:          schema.TypeString,
+		Required:      i.required,
+		Optional:      !i.required,
+		Sensitive:     i.secret,
+		Description:   i.description,
+		ValidateFunc:  i.validate,
+		RequiredWith:  i.requiredWith,
+		ConflictsWith: i.conflictsWith,
+	}
+	if i.boolean {
+		s.Type = schema.TypeBool
+		s.Default = false
+	}
+	if i.defaultValue != nil {
+		s.Default = i.defaultValue
+	}
+	if s.ValidateFunc == nil && s.Type == schema.TypeString && i.required {
+		s.ValidateFunc = validation.StringIsNotEmpty
+	}
+	return s
+}
+
+// credentialKind is a managed AWX credential type exposed as a typed
+// credential resource. The credential type is looked up by its namespace,
+// as the IDs of the managed credential types differ between installations.
+// metadata is only set for secret lookup plugins, it describes the metadata
+// of the input sources using a credential of the kind as source.
+type credentialKind struct {
+	name          string
+	namespace     string
+	inputs        []credentialInput
+	metadata      []credentialInput
+	customizeDiff schema.CustomizeDiffFunc
+}
+
+func resourceCredentialKind(k *credentialKind) *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of this credential",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Optional description of this credential",
+		},
+		"organization_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			Description: "Numeric ID of the organization owning the credential",
+		},
+	}
+	for _, input := range k.inputs {
+		s[input.attribute] = input.schema()
+	}
+
+	return &schema.Resource{
+		CreateContext: k.create,
+		ReadContext:   k.read,
+		UpdateContext: k.update,
+		DeleteContext: CredentialsServiceDeleteByID,
+		CustomizeDiff: k.customizeDiff,
+		Schema:        s,
+		Importer: &schema.ResourceImporter{
+			State: importStateByNamePath(namePathCredential),
+		},
+	}
+}
+
+// credentialNamespace returns the namespace of the credential type of a
+// credential, empty for credential types not managed by AWX.
+func credentialNamespace(ctx context.Context, m interface{}, credentialID int) (string, error) {
+	var cred awxCredential
+	if err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/credentials/%d/", credentialID), nil, &cred); err != nil {
+		return "", err
+	}
+	var credentialType struct {
+		Namespace string `json:"namespace"`
+	}
+	if err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/credential_types/%d/", cred.CredentialType), nil, &credentialType); err != nil {
+		return "", err
+	}
+	return credentialType.Namespace, nil
+}
+
+// credentialTypeIDByNamespace looks up the ID of a managed credential type.
+func credentialTypeIDByNamespace(ctx context.Context, m interface{}, namespace string) (int, error) {
+	types, err := getAPIClient(m).list(ctx, "/api/v2/credential_types/", map[string]string{
+		"namespace": namespace,
+		"managed":   "true",
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(types) != 1 {
+		return 0, fmt.Errorf("found %d managed credential types with the namespace %s, expected 1", len(types), namespace)
+	}
+	id, _ := types[0]["id"].(float64)
+	return int(id), nil
+}
+
+func (k *credentialKind) payload(d *schema.ResourceData) map[string]interface{} {
+	inputs := make(map[string]interface{})
+	for _, input := range k.inputs {
+		value := d.Get(input.attribute)
+		if s, ok := value.(string); ok && s == "" {
+			continue
+		}
+		inputs[input.inputID()] = value
+	}
+	return map[string]interface{}{
+		"name":         d.Get("name").(string),
+		"description":  d.Get("description").(string),
+		"organization": d.Get("organization_id").(int),
+		"inputs":       inputs,
+	}
+}
+
+func (k *credentialKind) create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	typeID, err := credentialTypeIDByNamespace(ctx, m, k.namespace)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Create: Credential not created",
+			"Unable to find the %s credential type, got %s", k.name, err.Error(),
+		)
+	}
+
+	payload := k.payload(d)
+	payload["credential_type"] = typeID
+	var cred awxCredential
+	if err := getAPIClient(m).post(ctx, "/api/v2/credentials/", payload, &cred); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: Credential not created",
+			"%s credential with name %s not created, %s", k.name, d.Get("name").(string), err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(cred.ID))
+	return k.read(ctx, d, m)
+}
+
+func (k *credentialKind) update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Update Credential", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := getAPIClient(m).patch(ctx, fmt.Sprintf("/api/v2/credentials/%d/", id), k.payload(d), nil); err != nil {
+		return buildDiagUpdateFail(k.name+" credential", id, err)
+	}
+	return k.read(ctx, d, m)
+}
+
+func (k *credentialKind) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read Credential", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	var cred awxCredential
+	err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/credentials/%d/", id), nil, &cred)
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return buildDiagNotFoundFail(k.name+" credential", id, err)
+	}
+
+	d.Set("name", cred.Name)
+	d.Set("description", cred.Description)
+	d.Set("organization_id", cred.Organization)
+	for _, input := range k.inputs {
+		value, ok := cred.Inputs[input.inputID()]
+		switch {
+		case input.secret && value == awxEncrypted:
+			// AWX does not return secrets, the configured value is kept.
+			continue
+		case !ok && input.boolean:
+			value = input.schema().Default
+		case !ok:
+			value = ""
+		}
+		d.Set(input.attribute, value)
+	}
+	return diags
+}
@@ -0,0 +1,186 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testCredentialKindsConfig(secretKey string) string {
+	return fmt.Sprintf(`
+resource "awx_organization" "test" {
+  name = "test-org"
+}
+
+resource "awx_credential_aws" "test" {
+  name            = "test-aws"
+  organization_id = awx_organization.test.id
+  access_key      = "AKIAEXAMPLE"
+  secret_key      = %q
+}
+
+resource "awx_credential_vault" "test" {
+  name            = "test-vault"
+  organization_id = awx_organization.test.id
+  vault_password  = "vault-secret"
+  vault_id        = "prod"
+}
+
+resource "awx_credential_network" "test" {
+  name               = "test-network"
+  organization_id    = awx_organization.test.id
+  username           = "admin"
+  password           = "secret"
+  authorize          = true
+  authorize_password = "enable"
+}
+
+resource "awx_credential_container_registry" "test" {
+  name            = "test-registry"
+  organization_id = awx_organization.test.id
+  username        = "robot"
+  password        = "token"
+}
+
+resource "awx_credential_kubernetes_bearer_token" "test" {
+  name            = "test-kubernetes"
+  organization_id = awx_organization.test.id
+  host            = "https://api.cluster.example.com:6443"
+  bearer_token    = "token"
+}
+
+resource "awx_credential_ansible_automation_platform" "test" {
+  name            = "test-controller"
+  organization_id = awx_organization.test.id
+  host            = "https://controller.example.com"
+  oauth_token     = "token"
+  verify_ssl      = true
+}
+
+resource "awx_credential_insights" "test" {
+  name            = "test-insights"
+  organization_id = awx_organization.test.id
+  username        = "insights"
+  password        = "secret"
+}
+
+resource "awx_credential_galaxy_api_token" "test" {
+  name            = "test-galaxy"
+  organization_id = awx_organization.test.id
+  url             = "https://galaxy.ansible.com/"
+  token           = "token"
+}
+`, secretKey)
+}
+
+// checkCredentialType checks that the credential behind name has the
+// managed credential type with the namespace.
+func (f *fakeAWX) checkCredentialType(name, namespace string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		cred, ok := f.collections["credentials"][int(fakeNumber(rs.Primary.ID))]
+		if !ok {
+			return fmt.Errorf("credential %s of %s not found", rs.Primary.ID, name)
+		}
+		credentialType := f.collections["credential_types"][int(fakeNumber(cred["credential_type"]))]
+		if got := fakeString(credentialType["namespace"]); got != namespace {
+			return fmt.Errorf("credential type of %s is %q, want %q", name, got, namespace)
+		}
+		return nil
+	}
+}
+
+func TestResourceCredentialKinds(t *testing.T) {
+	fake := newFakeAWX(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories(),
+		CheckDestroy:      fake.checkDestroy("awx_credential_aws", "credentials"),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.config(testCredentialKindsConfig("secret")),
+				Check: resource.ComposeTestCheckFunc(
+					fake.checkCredentialType("awx_credential_aws.test", "aws"),
+					fake.checkCredentialType("awx_credential_vault.test", "vault"),
+					fake.checkCredentialType("awx_credential_network.test", "net"),
+					fake.checkCredentialType("awx_credential_container_registry.test", "registry"),
+					fake.checkCredentialType("awx_credential_kubernetes_bearer_token.test", "kubernetes_bearer_token"),
+					fake.checkCredentialType("awx_credential_ansible_automation_platform.test", "controller"),
+					fake.checkCredentialType("awx_credential_insights.test", "insights"),
+					fake.checkCredentialType("awx_credential_galaxy_api_token.test", "galaxy_api_token"),
+					fake.checkField("awx_credential_aws.test", "credentials", "inputs", map[string]interface{}{"username": "AKIAEXAMPLE", "password": "secret"}),
+					fake.checkField("awx_credential_container_registry.test", "credentials", "inputs", map[string]interface{}{
+						"host":       "quay.io",
+						"username":   "robot",
+						"password":   "token",
+						"verify_ssl": true,
+					}),
+					resource.TestCheckResourceAttr("awx_credential_kubernetes_bearer_token.test", "verify_ssl", "false"),
+				),
+			},
+			{
+				// AWX does not return secrets.
+				PreConfig: func() {
+					fake.mu.Lock()
+					defer fake.mu.Unlock()
+					for _, cred := range fake.collections["credentials"] {
+						if cred["name"] == "test-aws" {
+							cred["inputs"].(map[string]interface{})["password"] = awxEncrypted
+						}
+					}
+				},
+				Config:   fake.config(testCredentialKindsConfig("secret")),
+				PlanOnly: true,
+			},
+			{
+				Config: fake.config(testCredentialKindsConfig("rotated")),
+				Check:  fake.checkField("awx_credential_aws.test", "credentials", "inputs", map[string]interface{}{"username": "AKIAEXAMPLE", "password": "rotated"}),
+			},
+			{
+				Config: fake.config(`
+resource "awx_credential_network" "test" {
+  name               = "test-network"
+  organization_id    = 1
+  username           = "admin"
+  authorize_password = "enable"
+}
+`),
+				ExpectError: regexp.MustCompile("authorize_password requires authorize to be enabled"),
+			},
+			{
+				Config: fake.config(`
+resource "awx_credential_kubernetes_bearer_token" "test" {
+  name            = "test-kubernetes"
+  organization_id = 1
+  host            = "api.cluster.example.com"
+  bearer_token    = "token"
+}
+`),
+				ExpectError: regexp.MustCompile(`expected "host" to have a host`),
+			},
+			{
+				Config: fake.config(`
+resource "awx_credential_vault" "test" {
+  name            = "test-vault"
+  organization_id = 1
+  vault_password  = "vault-secret"
+  vault_id        = "prod@prompt"
+}
+`),
+				ExpectError: regexp.MustCompile("must not contain whitespace or @"),
+			},
+			{
+				ResourceName:      "awx_credential_aws.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,395 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var surveyQuestionTypes = []string{"text", "textarea", "password", "integer", "float", "multiplechoice", "multiselect"}
+
+// validateSurveyLimit accepts an integer min or max, or an empty string for
+// none. They are strings so an explicit 0 can be told from an unset limit.
+var validateSurveyLimit = validation.StringMatch(regexp.MustCompile(`^(-?[0-9]+)?$`), "must be an integer")
+
+// surveySchema is the survey block shared by job templates and workflow job
+// templates. It is stored through the /survey_spec/ endpoint of the
+// template, survey_enabled still decides whether the survey is shown on
+// launch.
+var surveySchema = &schema.Schema{
+	Type:        schema.TypeList,
+	Optional:    true,
+	MaxItems:    1,
+	Description: "Survey shown on launch, requires survey_enabled to be set",
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Name of the survey",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Description of the survey",
+			},
+			"question": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Questions of the survey in the order they are asked",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"question_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Question shown to the user",
+						},
+						"question_description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Description shown below the question",
+						},
+						"variable": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Extra variable the answer is stored in",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(surveyQuestionTypes, false),
+							Description:  "Answer type, one of text, textarea, password, integer, float, multiplechoice or multiselect",
+						},
+						"required": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether an answer is required",
+						},
+						"default": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "",
+							DiffSuppressFunc: suppressSurveyNumericDefault,
+							Description:      "Default answer, multiselect defaults are separated by newlines",
+						},
+						"password_default": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Sensitive:   true,
+							Description: "Default answer of password questions",
+						},
+						"min": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "",
+							ValidateFunc: validateSurveyLimit,
+							Description:  "Minimum length of text answers or minimum value of numeric answers, unset for none",
+						},
+						"max": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "",
+							ValidateFunc: validateSurveyLimit,
+							Description:  "Maximum length of text answers or maximum value of numeric answers, unset for none",
+						},
+						"choices": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Choices of multiplechoice and multiselect questions",
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+// suppressSurveyNumericDefault compares the defaults of integer and float
+// questions numerically, AWX stores them as numbers.
+func suppressSurveyNumericDefault(k, old, new string, d *schema.ResourceData) bool {
+	questionType, _ := d.Get(strings.TrimSuffix(k, "default") + "type").(string)
+	if questionType != "integer" && questionType != "float" {
+		return false
+	}
+	return surveyNumbersEqual(old, new)
+}
+
+func surveyNumbersEqual(a, b string) bool {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	return errA == nil && errB == nil && x == y
+}
+
+// customizeDiffSurvey validates the survey block at plan time. Questions
+// with choices which are not known yet are skipped.
+func customizeDiffSurvey(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	surveys := d.Get("survey").([]interface{})
+	if len(surveys) == 0 || surveys[0] == nil {
+		return nil
+	}
+	survey := surveys[0].(map[string]interface{})
+
+	variables := make(map[string]bool)
+	for i, v := range survey["question"].([]interface{}) {
+		q := v.(map[string]interface{})
+		variable := q["variable"].(string)
+		if variable != "" && variables[variable] {
+			return fmt.Errorf("survey: variable %q is used by more than one question", variable)
+		}
+		variables[variable] = true
+
+		if !d.NewValueKnown(fmt.Sprintf("survey.0.question.%d.choices", i)) {
+			continue
+		}
+		if err := validateSurveyQuestion(q); err != nil {
+			return fmt.Errorf("survey: question %d (%s): %s", i+1, variable, err)
+		}
+	}
+	return nil
+}
+
+// validateSurveyQuestion checks the combination of type, default, min, max
+// and choices of a survey question.
+func validateSurveyQuestion(q map[string]interface{}) error {
+	questionType := q["type"].(string)
+	def := q["default"].(string)
+	passwordDefault, _ := q["password_default"].(string)
+	min, hasMin := surveyLimit(q["min"])
+	max, hasMax := surveyLimit(q["max"])
+	var choices []string
+	for _, c := range q["choices"].([]interface{}) {
+		choice, _ := c.(string)
+		choices = append(choices, choice)
+	}
+
+	if questionType == "password" && def != "" {
+		return fmt.Errorf("password questions take their default from password_default")
+	}
+	if questionType != "password" && passwordDefault != "" {
+		return fmt.Errorf("password_default is only supported by password questions")
+	}
+
+	switch questionType {
+	case "multiplechoice", "multiselect":
+		if len(choices) == 0 {
+			return fmt.Errorf("%s questions require choices", questionType)
+		}
+		if hasMin || hasMax {
+			return fmt.Errorf("min and max are not supported by %s questions", questionType)
+		}
+		defaults := []string{def}
+		if questionType == "multiselect" {
+			defaults = strings.Split(def, "\n")
+		}
+		for _, v := range defaults {
+			if v != "" && !surveyContains(choices, v) {
+				return fmt.Errorf("default %q is not one of the choices", v)
+			}
+		}
+		return nil
+	case "integer":
+		if _, err := strconv.Atoi(def); def != "" && err != nil {
+			return fmt.Errorf("default %q is not an integer", def)
+		}
+	case "float":
+		if _, err := strconv.ParseFloat(def, 64); def != "" && err != nil {
+			return fmt.Errorf("default %q is not a number", def)
+		}
+	}
+
+	if len(choices) > 0 {
+		return fmt.Errorf("choices are only supported by multiplechoice and multiselect questions")
+	}
+	if hasMin && min < 0 && questionType != "integer" && questionType != "float" {
+		return fmt.Errorf("min must not be negative for %s questions", questionType)
+	}
+	if hasMin && hasMax && min > max {
+		return fmt.Errorf("min %d is greater than max %d", min, max)
+	}
+	return nil
+}
+
+// surveyLimit returns the configured min or max of a question and whether
+// it is set.
+func surveyLimit(v interface{}) (int, bool) {
+	s, _ := v.(string)
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+func surveyContains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func surveySpecPath(collection string, id int) string {
+	return fmt.Sprintf("/api/v2/%s/%d/survey_spec/", collection, id)
+}
+
+// surveySpecUpdate stores the survey block as survey spec of a template, or
+// deletes the survey spec when the block was removed.
+func surveySpecUpdate(ctx context.Context, d *schema.ResourceData, m interface{}, collection string, id int) error {
+	surveys := d.Get("survey").([]interface{})
+	if len(surveys) == 0 || surveys[0] == nil {
+		err := getAPIClient(m).delete(ctx, surveySpecPath(collection, id))
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return getAPIClient(m).post(ctx, surveySpecPath(collection, id), expandSurveySpec(surveys[0].(map[string]interface{})), nil)
+}
+
+func expandSurveySpec(survey map[string]interface{}) map[string]interface{} {
+	var spec []interface{}
+	for _, v := range survey["question"].([]interface{}) {
+		q := v.(map[string]interface{})
+		question := map[string]interface{}{
+			"question_name":        q["question_name"],
+			"question_description": q["question_description"],
+			"variable":             q["variable"],
+			"type":                 q["type"],
+			"required":             q["required"],
+		}
+
+		def := q["default"].(string)
+		question["default"] = def
+		switch q["type"].(string) {
+		case "password":
+			question["default"] = q["password_default"]
+		case "integer":
+			if n, err := strconv.Atoi(def); err == nil {
+				question["default"] = n
+			}
+		case "float":
+			if n, err := strconv.ParseFloat(def, 64); err == nil {
+				question["default"] = n
+			}
+		}
+		if min, ok := surveyLimit(q["min"]); ok {
+			question["min"] = min
+		}
+		if max, ok := surveyLimit(q["max"]); ok {
+			question["max"] = max
+		}
+
+		var choices []string
+		for _, c := range q["choices"].([]interface{}) {
+			choice, _ := c.(string)
+			choices = append(choices, choice)
+		}
+		if len(choices) > 0 {
+			question["choices"] = strings.Join(choices, "\n")
+		}
+		spec = append(spec, question)
+	}
+
+	return map[string]interface{}{
+		"name":        survey["name"],
+		"description": survey["description"],
+		"spec":        spec,
+	}
+}
+
+// setSurveyResourceData reads the survey spec of a template into the survey
+// block. AWX returns an empty object for templates without a survey and
+// $encrypted$ as default of password questions, these defaults are taken
+// from the current block instead, as are numeric defaults equal to the
+// configured ones.
+func setSurveyResourceData(ctx context.Context, d *schema.ResourceData, m interface{}, collection string, id int) error {
+	var spec struct {
+		Name        string                   `json:"name"`
+		Description string                   `json:"description"`
+		Spec        []map[string]interface{} `json:"spec"`
+	}
+	if err := getAPIClient(m).get(ctx, surveySpecPath(collection, id), nil, &spec); err != nil && !isNotFound(err) {
+		return err
+	}
+	if len(spec.Spec) == 0 {
+		return d.Set("survey", nil)
+	}
+
+	current := make(map[string]map[string]interface{})
+	if surveys := d.Get("survey").([]interface{}); len(surveys) > 0 && surveys[0] != nil {
+		for _, v := range surveys[0].(map[string]interface{})["question"].([]interface{}) {
+			q := v.(map[string]interface{})
+			current[q["variable"].(string)] = q
+		}
+	}
+
+	var questions []interface{}
+	for _, q := range spec.Spec {
+		question := map[string]interface{}{
+			"question_name":        q["question_name"],
+			"question_description": q["question_description"],
+			"variable":             q["variable"],
+			"type":                 q["type"],
+			"required":             q["required"],
+			"default":              "",
+			"password_default":     "",
+			"min":                  "",
+			"max":                  "",
+		}
+		variable, _ := q["variable"].(string)
+		currentDefault, _ := current[variable]["default"].(string)
+		switch v := q["default"].(type) {
+		case string:
+			question["default"] = v
+			if q["type"] == "password" {
+				question["default"] = ""
+				question["password_default"] = v
+				if v == awxEncrypted {
+					question["password_default"], _ = current[variable]["password_default"].(string)
+				}
+			}
+		case float64:
+			question["default"] = strconv.FormatFloat(v, 'f', -1, 64)
+			if surveyNumbersEqual(currentDefault, question["default"].(string)) {
+				question["default"] = currentDefault
+			}
+		}
+		for _, key := range []string{"min", "max"} {
+			if n, ok := q[key].(float64); ok {
+				question[key] = strconv.Itoa(int(n))
+			}
+		}
+
+		var choices []interface{}
+		switch v := q["choices"].(type) {
+		case string:
+			for _, c := range strings.Split(v, "\n") {
+				if c != "" {
+					choices = append(choices, c)
+				}
+			}
+		case []interface{}:
+			choices = v
+		}
+		question["choices"] = choices
+		questions = append(questions, question)
+	}
+
+	return d.Set("survey", []interface{}{
+		map[string]interface{}{
+			"name":        spec.Name,
+			"description": spec.Description,
+			"question":    questions,
+		},
+	})
+}
@@ -0,0 +1,81 @@
+package awx
+
+import (
+	"testing"
+)
+
+func TestValidateSurveyQuestion(t *testing.T) {
+	question := func(questionType, def, min, max string, choices ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"type":    questionType,
+			"default": def,
+			"min":     min,
+			"max":     max,
+			"choices": choices,
+		}
+	}
+
+	withPasswordDefault := func(q map[string]interface{}) map[string]interface{} {
+		q["password_default"] = "secret"
+		return q
+	}
+
+	cases := []struct {
+		name     string
+		question map[string]interface{}
+		valid    bool
+	}{
+		{"text", question("text", "hello", "", "1024"), true},
+		{"integer", question("integer", "5", "1", "10"), true},
+		{"negative integer", question("integer", "-5", "-10", "10"), true},
+		{"non-negative integer", question("integer", "5", "0", ""), true},
+		{"non-positive integer", question("integer", "-5", "-10", "0"), true},
+		{"float", question("float", "0.5", "", "1"), true},
+		{"multiplechoice", question("multiplechoice", "b", "", "", "a", "b"), true},
+		{"multiselect", question("multiselect", "a\nb", "", "", "a", "b", "c"), true},
+		{"multiselect without default", question("multiselect", "", "", "", "a"), true},
+		{"integer default", question("integer", "five", "", ""), false},
+		{"float default", question("float", "half", "", ""), false},
+		{"min greater than max", question("text", "", "10", "5"), false},
+		{"min greater than max 0", question("integer", "", "5", "0"), false},
+		{"negative text length", question("text", "", "-1", "5"), false},
+		{"choices on text", question("text", "", "", "", "a"), false},
+		{"multiplechoice without choices", question("multiplechoice", "", "", ""), false},
+		{"multiplechoice with max", question("multiplechoice", "", "", "5", "a"), false},
+		{"multiplechoice with min 0", question("multiplechoice", "", "0", "", "a"), false},
+		{"unknown default", question("multiplechoice", "c", "", "", "a", "b"), false},
+		{"unknown multiselect default", question("multiselect", "a\nc", "", "", "a", "b"), false},
+		{"password default", question("password", "secret", "", ""), false},
+		{"password_default on text", withPasswordDefault(question("text", "", "", "")), false},
+		{"password_default", withPasswordDefault(question("password", "", "", "")), true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateSurveyQuestion(c.question)
+			if c.valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !c.valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestSurveyNumbersEqual(t *testing.T) {
+	cases := []struct {
+		a, b  string
+		equal bool
+	}{
+		{"2", "2.0", true},
+		{"1.5", "1.50", true},
+		{"1.5", "1.05", false},
+		{"", "0", false},
+		{"two", "two", false},
+	}
+	for _, c := range cases {
+		if got := surveyNumbersEqual(c.a, c.b); got != c.equal {
+			t.Errorf("surveyNumbersEqual(%q, %q) = %t, want %t", c.a, c.b, got, c.equal)
+		}
+	}
+}
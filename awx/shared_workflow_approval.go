@@ -0,0 +1,124 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// awxWorkflowApprovalTemplate is the template of an approval step in a
+// workflow.
+type awxWorkflowApprovalTemplate struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Timeout     int    `json:"timeout"`
+}
+
+// workflowApprovalSchema is the approval step a workflow node runs instead
+// of a unified job template. The workflow pauses until the approval is
+// approved or denied.
+var workflowApprovalSchema = &schema.Schema{
+	Type:        schema.TypeList,
+	Optional:    true,
+	MaxItems:    1,
+	Description: "Approval step run by the node instead of a unified job template",
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the approval",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Description of the approval",
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Seconds until the approval times out and the node fails, 0 waits forever",
+			},
+		},
+	},
+}
+
+// workflowNodeApprovalTemplateID returns the ID of the approval template a
+// decoded AWX workflow node runs, 0 for nodes running other templates.
+func workflowNodeApprovalTemplateID(node map[string]interface{}) int {
+	summary, _ := node["summary_fields"].(map[string]interface{})
+	template, _ := summary["unified_job_template"].(map[string]interface{})
+	if template["unified_job_type"] != "workflow_approval" {
+		return 0
+	}
+	if id, ok := node["unified_job_template"].(float64); ok {
+		return int(id)
+	}
+	return 0
+}
+
+// getWorkflowApproval reads an approval template as approval block.
+func getWorkflowApproval(ctx context.Context, m interface{}, templateID int) (map[string]interface{}, error) {
+	var template awxWorkflowApprovalTemplate
+	if err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/workflow_approval_templates/%d/", templateID), nil, &template); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"name":        template.Name,
+		"description": template.Description,
+		"timeout":     template.Timeout,
+	}, nil
+}
+
+// workflowApprovalUpdate creates the approval template of a workflow node
+// or updates the existing one with the ID templateID.
+func workflowApprovalUpdate(ctx context.Context, m interface{}, nodeID, templateID int, approval map[string]interface{}) error {
+	payload := map[string]interface{}{
+		"name":        approval["name"].(string),
+		"description": approval["description"].(string),
+		"timeout":     approval["timeout"].(int),
+	}
+	if templateID == 0 {
+		return getAPIClient(m).post(ctx, fmt.Sprintf("/api/v2/workflow_job_template_nodes/%d/create_approval_template/", nodeID), payload, nil)
+	}
+	return getAPIClient(m).patch(ctx, fmt.Sprintf("/api/v2/workflow_approval_templates/%d/", templateID), payload, nil)
+}
+
+// workflowNodeApprovalUpdate applies the approval block of a workflow node
+// resource.
+func workflowNodeApprovalUpdate(ctx context.Context, d *schema.ResourceData, m interface{}, nodeID int) error {
+	approvals := d.Get("approval").([]interface{})
+	if len(approvals) == 0 || approvals[0] == nil || !d.HasChange("approval") {
+		return nil
+	}
+
+	var node map[string]interface{}
+	if err := getAPIClient(m).get(ctx, fmt.Sprintf("/api/v2/workflow_job_template_nodes/%d/", nodeID), nil, &node); err != nil {
+		return err
+	}
+	return workflowApprovalUpdate(ctx, m, nodeID, workflowNodeApprovalTemplateID(node), approvals[0].(map[string]interface{}))
+}
+
+// setWorkflowApprovalResourceData reads the approval of a decoded AWX node
+// into a workflow node resource. unified_job_template_id is cleared for
+// approval nodes, as the approval template is managed through the approval
+// block.
+func setWorkflowApprovalResourceData(ctx context.Context, d *schema.ResourceData, m interface{}, node map[string]interface{}) error {
+	templateID := workflowNodeApprovalTemplateID(node)
+	if templateID == 0 {
+		return d.Set("approval", nil)
+	}
+
+	approval, err := getWorkflowApproval(ctx, m, templateID)
+	if err != nil {
+		return err
+	}
+	d.Set("unified_job_template_id", 0)
+	return d.Set("approval", []interface{}{approval})
+}
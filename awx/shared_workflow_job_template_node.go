@@ -8,10 +8,11 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	awx "github.com/mrcrilly/goawx/client"
 )
 
-var workflowJobNodeSchema = map[string]*schema.Schema{
+var workflowJobNodeSchema = withWorkflowNodePrompts(map[string]*schema.Schema{
 
 	"extra_data": {
 		Type:        schema.TypeString,
@@ -66,9 +67,12 @@ var workflowJobNodeSchema = map[string]*schema.Schema{
 	//	Required: true,
 	//},
 	"unified_job_template_id": {
-		Type:     schema.TypeInt,
-		Required: true,
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ExactlyOneOf: []string{"unified_job_template_id", "approval"},
+		Description:  "Numeric ID of the job template, project, inventory source or workflow run by the node, required unless approval is set",
 	},
+	"approval": workflowApprovalSchema,
 	"all_parents_must_converge": {
 		Type:     schema.TypeBool,
 		Optional: true,
@@ -78,30 +82,33 @@ var workflowJobNodeSchema = map[string]*schema.Schema{
 		Type:     schema.TypeString,
 		Required: true,
 	},
-}
+	"success_nodes":       workflowNodeEdgeSchema("success"),
+	"failure_nodes":       workflowNodeEdgeSchema("failure"),
+	"always_nodes":        workflowNodeEdgeSchema("always"),
+	"authoritative_edges": workflowNodeAuthoritativeEdgesSchema,
+})
 
 func createNodeForWorkflowJob(awxService *awx.WorkflowJobTemplateNodeStepService, ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	templateNodeID := d.Get("workflow_job_template_node_id").(int)
-	result, err := awxService.CreateWorkflowJobTemplateNodeStep(templateNodeID, map[string]interface{}{
-		//"extra_data": d.Get("extra_data").(string),
-		"inventory":  d.Get("inventory_id").(int),
-		"scm_branch": d.Get("scm_branch").(string),
-		"skip_tags":  d.Get("skip_tags").(string),
-		"job_type":   d.Get("job_type").(string),
-		"job_tags":   d.Get("job_tags").(string),
-		//"limit":      d.Get("limit").(string),
-		//"diff_mode":  d.Get("diff_mode").(bool),
-		"verbosity": d.Get("verbosity").(int),
-		//"workflow_job_template": d.Get("workflow_job_template_id").(int),
-		"unified_job_template": d.Get("unified_job_template_id").(int),
-		//"failure_nodes":         d.Get("failure_nodes").([]interface{}),
-		//"success_nodes":         d.Get("success_nodes").([]interface{}),
-		//"always_nodes":          d.Get("always_nodes").([]interface{}),
-
+	payload := map[string]interface{}{
+		"extra_data":                d.Get("extra_data").(string),
+		"inventory":                 d.Get("inventory_id").(int),
+		"scm_branch":                d.Get("scm_branch").(string),
+		"skip_tags":                 d.Get("skip_tags").(string),
+		"job_type":                  d.Get("job_type").(string),
+		"job_tags":                  d.Get("job_tags").(string),
+		"limit":                     d.Get("limit").(string),
+		"diff_mode":                 d.Get("diff_mode").(bool),
+		"verbosity":                 d.Get("verbosity").(int),
+		"unified_job_template":      optionalID(d.Get("unified_job_template_id").(int)),
 		"all_parents_must_converge": d.Get("all_parents_must_converge").(bool),
 		"identifier":                d.Get("identifier").(string),
-	}, map[string]string{})
+	}
+	for k, v := range workflowNodePromptPayload(d.Get) {
+		payload[k] = v
+	}
+	result, err := awxService.CreateWorkflowJobTemplateNodeStep(templateNodeID, payload, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Template %v", err)
 		diags = append(diags, diag.Diagnostic{
@@ -113,5 +120,432 @@ func createNodeForWorkflowJob(awxService *awx.WorkflowJobTemplateNodeStepService
 	}
 	log.Printf("dasdasdasdas %v", result)
 	d.SetId(strconv.Itoa(result.ID))
+	if err := workflowNodeApprovalUpdate(ctx, d, m, result.ID); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: WorkflowJobTemplateNode approval not created",
+			"Fail to create the approval of WorkflowJobTemplateNode with ID %v, got %s",
+			result.ID, err.Error(),
+		)
+	}
+	if err := workflowNodePromptsUpdate(ctx, d, m, result.ID); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: WorkflowJobTemplateNode prompts not set",
+			"Fail to set the credentials, labels and instance groups of WorkflowJobTemplateNode with ID %v, got %s",
+			result.ID, err.Error(),
+		)
+	}
+	if err := workflowNodeEdgesUpdate(ctx, d, m, result.ID); err != nil {
+		return buildDiagnosticsMessage(
+			"Create: WorkflowJobTemplateNode edges not linked",
+			"Fail to link the child nodes of WorkflowJobTemplateNode with ID %v, got %s",
+			result.ID, err.Error(),
+		)
+	}
 	return resourceWorkflowJobTemplateNodeRead(ctx, d, m)
 }
+
+// importNodeForWorkflowJob imports a success, failure or always node by its
+// parent node and the node itself separated by a colon, each given by its ID
+// or its name path.
+func importNodeForWorkflowJob(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids, err := splitImportID(m, d.Id(),
+		importIDPart{"<workflow_job_template_node>", &namePathWorkflowJobTemplateNode},
+		importIDPart{"<node>", &namePathWorkflowJobTemplateNode},
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.Set("workflow_job_template_node_id", ids[0])
+	d.SetId(strconv.Itoa(ids[1]))
+	return []*schema.ResourceData{d}, nil
+}
+
+// workflowNodeEdges are the related endpoints of a workflow job template node
+// linking it to the nodes run after it.
+var workflowNodeEdges = []string{"success_nodes", "failure_nodes", "always_nodes"}
+
+// workflowNodeEdgeSchema is the set of child nodes run after a node
+// finished with the given result. The set is computed when it is not
+// configured, so edges created by awx_workflow_job_template_node_success,
+// _failure and _always resources do not show up as changes of the parent.
+func workflowNodeEdgeSchema(result string) *schema.Schema {
+	description := fmt.Sprintf("Numeric IDs of the nodes run after this node on %s", result)
+	if result == "always" {
+		description = "Numeric IDs of the nodes run after this node regardless of its result"
+	}
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Elem:        &schema.Schema{Type: schema.TypeInt},
+		Optional:    true,
+		Computed:    true,
+		Description: description,
+	}
+}
+
+// workflowNodeAuthoritativeEdgesSchema makes the edge sets list every child
+// of the node, so leaving a set out or setting it to [] unlinks all children
+// of that kind.
+var workflowNodeAuthoritativeEdgesSchema = &schema.Schema{
+	Type:        schema.TypeBool,
+	Optional:    true,
+	Default:     false,
+	Description: "If true success_nodes, failure_nodes and always_nodes list every child of the node, a set left out or empty unlinks all children of that kind",
+}
+
+// customizeDiffWorkflowNodeEdges rejects a child node linked by more than
+// one edge of the same parent at plan time, AWX only allows one. With
+// authoritative_edges, the edge sets missing from the configuration are
+// planned empty, as the SDK does not tell an empty set from a missing one.
+func customizeDiffWorkflowNodeEdges(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if config := d.GetRawConfig(); d.Get("authoritative_edges").(bool) && !config.IsNull() && config.IsKnown() {
+		for _, edge := range workflowNodeEdges {
+			v := config.GetAttr(edge)
+			if !v.IsNull() && (!v.IsKnown() || v.LengthInt() > 0) {
+				continue
+			}
+			if d.Get(edge).(*schema.Set).Len() == 0 {
+				continue
+			}
+			if err := d.SetNew(edge, schema.NewSet(schema.HashInt, nil)); err != nil {
+				return err
+			}
+		}
+	}
+
+	linked := make(map[int]string)
+	for _, edge := range workflowNodeEdges {
+		if !d.NewValueKnown(edge) {
+			continue
+		}
+		for _, v := range d.Get(edge).(*schema.Set).List() {
+			nodeID := v.(int)
+			if other, ok := linked[nodeID]; ok {
+				return fmt.Errorf("node %d is listed in both %s and %s", nodeID, other, edge)
+			}
+			linked[nodeID] = edge
+		}
+		if id, err := strconv.Atoi(d.Id()); err == nil && d.Get(edge).(*schema.Set).Contains(id) {
+			return fmt.Errorf("%s must not contain the node itself", edge)
+		}
+	}
+	return nil
+}
+
+// workflowNodeEdgesUpdate links and unlinks child nodes of a workflow job
+// template node. All removed edges are unlinked before new ones are linked,
+// so a child can move from one edge to another in a single apply.
+func workflowNodeEdgesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}, id int) error {
+	type change struct {
+		path    string
+		payload map[string]interface{}
+	}
+	var remove, add []change
+	for _, edge := range workflowNodeEdges {
+		if !d.HasChange(edge) {
+			continue
+		}
+		o, n := d.GetChange(edge)
+		oldSet, newSet := o.(*schema.Set), n.(*schema.Set)
+
+		path := fmt.Sprintf("/api/v2/workflow_job_template_nodes/%d/%s/", id, edge)
+		for _, v := range oldSet.Difference(newSet).List() {
+			remove = append(remove, change{path, map[string]interface{}{
+				"id":           v.(int),
+				"disassociate": true, // presence of key triggers removal
+			}})
+		}
+		for _, v := range newSet.Difference(oldSet).List() {
+			add = append(add, change{path, map[string]interface{}{
+				"id": v.(int),
+			}})
+		}
+	}
+
+	for _, c := range append(remove, add...) {
+		if err := getAPIClient(m).post(ctx, c.path, c.payload, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setWorkflowNodeEdgesResourceData reads the child nodes of a workflow job
+// template node.
+func setWorkflowNodeEdgesResourceData(ctx context.Context, d *schema.ResourceData, m interface{}, id int) error {
+	// authoritative_edges is not stored in AWX, imports get the default.
+	d.Set("authoritative_edges", d.Get("authoritative_edges").(bool))
+	for _, edge := range workflowNodeEdges {
+		nodes, err := getAPIClient(m).list(ctx, fmt.Sprintf("/api/v2/workflow_job_template_nodes/%d/%s/", id, edge), nil)
+		if err != nil {
+			return err
+		}
+
+		var nodeIDs []interface{}
+		for _, n := range nodes {
+			if nodeID, ok := n["id"].(float64); ok {
+				nodeIDs = append(nodeIDs, int(nodeID))
+			}
+		}
+		if err := d.Set(edge, schema.NewSet(schema.HashInt, nodeIDs)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// workflowNodePromptSchema are the prompts of a workflow node beyond the
+// original node fields. They are shared by the node resources and the nodes
+// of awx_workflow_job_template_graph.
+func workflowNodePromptSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"execution_environment_id": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Numeric ID of the execution environment applied as a prompt",
+		},
+		"forks": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Number of forks applied as a prompt, 0 keeps the template default",
+		},
+		"timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Job timeout in seconds applied as a prompt, 0 keeps the template default",
+		},
+		"job_slice_count": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Number of job slices applied as a prompt, 0 keeps the template default",
+		},
+		"credential_ids": {
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeInt},
+			Optional:    true,
+			Description: "Numeric IDs of the credentials applied as a prompt",
+		},
+		"label_ids": {
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeInt},
+			Optional:    true,
+			Description: "Numeric IDs of the labels applied as a prompt",
+		},
+		"instance_group_ids": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeInt},
+			Optional:    true,
+			Description: "Numeric IDs of the instance groups applied as a prompt, in the order AWX falls back to them",
+		},
+	}
+}
+
+// withWorkflowNodePrompts adds the prompts of workflowNodePromptSchema to a
+// node schema.
+func withWorkflowNodePrompts(s map[string]*schema.Schema) map[string]*schema.Schema {
+	for k, v := range workflowNodePromptSchema() {
+		s[k] = v
+	}
+	return s
+}
+
+// workflowNodePromptAssociations are the prompts stored through related
+// endpoints of a node. AWX falls back to instance groups in the order they
+// were associated.
+var workflowNodePromptAssociations = []struct {
+	key     string
+	related string
+	ordered bool
+}{
+	{key: "credential_ids", related: "credentials"},
+	{key: "label_ids", related: "labels"},
+	{key: "instance_group_ids", related: "instance_groups", ordered: true},
+}
+
+// workflowNodePromptPayload returns the scalar prompts of
+// workflowNodePromptSchema, read through get from the resource or a node
+// block. Prompts which are not set are sent as null.
+func workflowNodePromptPayload(get func(key string) interface{}) map[string]interface{} {
+	payload := map[string]interface{}{
+		"execution_environment": optionalID(get("execution_environment_id").(int)),
+	}
+	for _, key := range []string{"forks", "timeout", "job_slice_count"} {
+		payload[key] = nil
+		if v := get(key).(int); v != 0 {
+			payload[key] = v
+		}
+	}
+	return payload
+}
+
+// flattenWorkflowNodePrompts returns the scalar prompts of
+// workflowNodePromptSchema of a decoded AWX node.
+func flattenWorkflowNodePrompts(node map[string]interface{}) map[string]interface{} {
+	prompts := map[string]interface{}{
+		"execution_environment_id": 0,
+		"forks":                    0,
+		"timeout":                  0,
+		"job_slice_count":          0,
+	}
+	if id, ok := node["execution_environment"].(float64); ok {
+		prompts["execution_environment_id"] = int(id)
+	}
+	for _, key := range []string{"forks", "timeout", "job_slice_count"} {
+		if v, ok := node[key].(float64); ok {
+			prompts[key] = int(v)
+		}
+	}
+	return prompts
+}
+
+// workflowNodeAssociations reads the credentials, labels and instance groups
+// of a node, keyed like workflowNodePromptAssociations.
+func workflowNodeAssociations(ctx context.Context, m interface{}, nodeID int) (map[string][]int, error) {
+	associations := make(map[string][]int)
+	for _, a := range workflowNodePromptAssociations {
+		objects, err := getAPIClient(m).list(ctx, fmt.Sprintf("/api/v2/workflow_job_template_nodes/%d/%s/", nodeID, a.related), nil)
+		if err != nil {
+			return nil, err
+		}
+		ids := []int{}
+		for _, o := range objects {
+			if id, ok := o["id"].(float64); ok {
+				ids = append(ids, int(id))
+			}
+		}
+		associations[a.key] = ids
+	}
+	return associations, nil
+}
+
+// workflowNodeAssociationsUpdate turns the credentials, labels and instance
+// groups of a node from current into wanted. All removals are applied before
+// additions, AWX rejects two credentials of the same type on a node.
+func workflowNodeAssociationsUpdate(ctx context.Context, m interface{}, nodeID int, current, wanted map[string][]int) error {
+	type change struct {
+		path    string
+		payload map[string]interface{}
+	}
+	var remove, add []change
+	for _, a := range workflowNodePromptAssociations {
+		path := fmt.Sprintf("/api/v2/workflow_job_template_nodes/%d/%s/", nodeID, a.related)
+
+		var removeIDs, addIDs []int
+		if a.ordered {
+			removeIDs, addIDs = instanceGroupChanges(current[a.key], wanted[a.key])
+		} else {
+			removeIDs, addIDs = intSetChanges(current[a.key], wanted[a.key])
+		}
+		for _, id := range removeIDs {
+			remove = append(remove, change{path, map[string]interface{}{
+				"id":           id,
+				"disassociate": true, // presence of key triggers removal
+			}})
+		}
+		for _, id := range addIDs {
+			add = append(add, change{path, map[string]interface{}{
+				"id": id,
+			}})
+		}
+	}
+
+	for _, c := range append(remove, add...) {
+		if err := getAPIClient(m).post(ctx, c.path, c.payload, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// intSetChanges returns the IDs of current missing in wanted and the IDs of
+// wanted missing in current.
+func intSetChanges(current, wanted []int) (remove, add []int) {
+	in := func(ids []int, id int) bool {
+		for _, v := range ids {
+			if v == id {
+				return true
+			}
+		}
+		return false
+	}
+	for _, id := range current {
+		if !in(wanted, id) {
+			remove = append(remove, id)
+		}
+	}
+	for _, id := range wanted {
+		if !in(current, id) {
+			add = append(add, id)
+		}
+	}
+	return remove, add
+}
+
+// workflowNodeAssociationIDs returns the IDs of the association prompts of a
+// node block or of the values returned by the ResourceData getter get.
+func workflowNodeAssociationIDs(get func(key string) interface{}) map[string][]int {
+	associations := make(map[string][]int)
+	for _, a := range workflowNodePromptAssociations {
+		var values []interface{}
+		switch v := get(a.key).(type) {
+		case *schema.Set:
+			values = v.List()
+		case []interface{}:
+			values = v
+		}
+		ids := []int{}
+		for _, id := range values {
+			ids = append(ids, id.(int))
+		}
+		associations[a.key] = ids
+	}
+	return associations
+}
+
+// workflowNodePromptsUpdate applies changed association prompts of a node
+// resource.
+func workflowNodePromptsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}, nodeID int) error {
+	changed := false
+	for _, a := range workflowNodePromptAssociations {
+		changed = changed || d.HasChange(a.key)
+	}
+	if !changed {
+		return nil
+	}
+	current := workflowNodeAssociationIDs(func(key string) interface{} {
+		o, _ := d.GetChange(key)
+		return o
+	})
+	return workflowNodeAssociationsUpdate(ctx, m, nodeID, current, workflowNodeAssociationIDs(d.Get))
+}
+
+// setWorkflowNodePromptsResourceData reads the prompts of
+// workflowNodePromptSchema of a node resource from the decoded AWX node and
+// the related endpoints of the node.
+func setWorkflowNodePromptsResourceData(ctx context.Context, d *schema.ResourceData, m interface{}, node map[string]interface{}) error {
+	for k, v := range flattenWorkflowNodePrompts(node) {
+		d.Set(k, v)
+	}
+
+	associations, err := workflowNodeAssociations(ctx, m, workflowGraphInt(node["id"]))
+	if err != nil {
+		return err
+	}
+	for _, a := range workflowNodePromptAssociations {
+		var ids []interface{}
+		for _, id := range associations[a.key] {
+			ids = append(ids, id)
+		}
+		if a.ordered {
+			d.Set(a.key, ids)
+		} else {
+			d.Set(a.key, schema.NewSet(schema.HashInt, ids))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	authMethodBasic  = "basic"
+	authMethodToken  = "token"
+	authMethodOAuth2 = "oauth2"
+)
+
+// tokenSource supplies the bearer token sent with every API request.
+type tokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+type staticTokenSource string
+
+func (s staticTokenSource) Token(ctx context.Context) (string, error) {
+	return string(s), nil
+}
+
+// oauth2ClientCredentialsSource fetches an access token from the AWX
+// /api/o/token/ endpoint using the client credentials grant and renews it
+// shortly before it expires.
+type oauth2ClientCredentialsSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scope        string
+	client       *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+func (s *oauth2ClientCredentialsSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || time.Now().Add(time.Minute).Before(s.expiry)) {
+		return s.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if s.scope != "" {
+		form.Set("scope", s.scope)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.clientID), url.QueryEscape(s.clientSecret))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request to %s failed with %s: %s", s.tokenURL, resp.Status, body)
+	}
+
+	var tok oauth2TokenResponse
+	if err := json.Unmarshal(body, &tok); err != nil {
+		return "", fmt.Errorf("unable to parse token response: %s", err)
+	}
+	if tok.AccessToken == "" {
+		return "", fmt.Errorf("token response from %s contains no access_token", s.tokenURL)
+	}
+
+	s.token = tok.AccessToken
+	s.expiry = time.Time{}
+	if tok.ExpiresIn > 0 {
+		s.expiry = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
+	}
+	return s.token, nil
+}
+
+// authTransport sets the Authorization header of every request. goawx
+// always adds basic auth credentials, so the header is replaced rather
+// than added.
+type authTransport struct {
+	base     http.RoundTripper
+	username string
+	password string
+	tokens   tokenSource
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	if t.tokens != nil {
+		token, err := t.tokens.Token(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		r.Header.Set("Authorization", "Bearer "+token)
+	} else {
+		r.SetBasicAuth(t.username, t.password)
+	}
+	return t.base.RoundTrip(r)
+}
+
+// checkAuthentication requests the current user which fails with 401 if the
+// configured credentials are not accepted. The ping endpoint used by goawx
+// does not require authentication.
+func checkAuthentication(ctx context.Context, a *apiClient) error {
+	return a.get(ctx, "/api/v2/me/", nil, nil)
+}
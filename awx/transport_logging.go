@@ -0,0 +1,142 @@
+package awx
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+const (
+	redactedValue       = "<redacted>"
+	maxLoggedBodyLength = 16 * 1024
+)
+
+// sensitiveFieldParts marks every JSON field whose name contains one of
+// these parts as sensitive. Credential inputs and notification
+// configurations use many different names for secrets, like ssh_key_data or
+// the PagerDuty service_key, so the match is deliberately broad.
+var sensitiveFieldParts = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"_key",
+	"authorization",
+}
+
+// loggingTransport logs method, path, status, latency and request id of
+// every AWX API call. With TF_LOG=DEBUG the request and response bodies are
+// logged as well, with sensitive fields redacted.
+type loggingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	debug := logging.IsDebugOrHigher()
+	if debug && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			if len(data) > 0 {
+				log.Printf("[DEBUG] AWX API request: method=%s path=%s body=%s", req.Method, req.URL.Path, redactBody(data))
+			}
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		log.Printf("[DEBUG] AWX API call: method=%s path=%s error=%q latency=%s", req.Method, req.URL.Path, err.Error(), latency)
+		return resp, err
+	}
+
+	log.Printf(
+		"[DEBUG] AWX API call: method=%s path=%s status=%d latency=%s request_id=%s",
+		req.Method, req.URL.Path, resp.StatusCode, latency, responseRequestID(resp),
+	)
+	if debug && resp.Body != nil {
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		if readErr != nil {
+			return resp, readErr
+		}
+		if len(data) > 0 {
+			log.Printf("[DEBUG] AWX API response: method=%s path=%s body=%s", req.Method, req.URL.Path, redactBody(data))
+		}
+	}
+	return resp, nil
+}
+
+func responseRequestID(resp *http.Response) string {
+	for _, h := range []string{"X-API-Request-Id", "X-Request-Id"} {
+		if id := resp.Header.Get(h); id != "" {
+			return id
+		}
+	}
+	return "-"
+}
+
+// redactBody returns the body for logging. JSON bodies are re-encoded with
+// the values of sensitive fields replaced; other bodies are only logged if
+// they do not look like they contain secrets.
+func redactBody(data []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		if isSensitiveField(string(data)) {
+			return redactedValue
+		}
+		return truncateBody(string(data))
+	}
+	b, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return redactedValue
+	}
+	return truncateBody(string(b))
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if isSensitiveField(k) {
+				if s, ok := item.(string); !ok || s != "" {
+					val[k] = redactedValue
+				}
+				continue
+			}
+			val[k] = redactValue(item)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = redactValue(item)
+		}
+		return val
+	}
+	return v
+}
+
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, part := range sensitiveFieldParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateBody(s string) string {
+	if len(s) > maxLoggedBodyLength {
+		return s[:maxLoggedBodyLength] + "...(truncated)"
+	}
+	return s
+}
@@ -0,0 +1,50 @@
+package awx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	cases := []struct {
+		body, want string
+	}{
+		{
+			`{"name": "admin", "password": "s3cret", "description": ""}`,
+			`{"description": "", "name": "admin", "password": "<redacted>"}`,
+		},
+		{
+			`{"inputs": {"username": "root", "ssh_key_data": "-----BEGIN", "ssh_key_unlock": ""}}`,
+			`{"inputs": {"ssh_key_data": "<redacted>", "ssh_key_unlock": "", "username": "root"}}`,
+		},
+		{
+			`{"notification_type": "pagerduty", "notification_configuration": {"subdomain": "acme", "service_key": "abc123", "client_name": "awx", "token": "xyz"}}`,
+			`{"notification_type": "pagerduty", "notification_configuration": {"subdomain": "acme", "service_key": "<redacted>", "client_name": "awx", "token": "<redacted>"}}`,
+		},
+		{
+			`{"results": [{"id": 1, "webhook_key": "k", "host_config_key": "c"}]}`,
+			`{"results": [{"id": 1, "webhook_key": "<redacted>", "host_config_key": "<redacted>"}]}`,
+		},
+	}
+	for _, c := range cases {
+		got := redactBody([]byte(c.body))
+		var gotValue, wantValue interface{}
+		if err := json.Unmarshal([]byte(got), &gotValue); err != nil {
+			t.Fatalf("redactBody(%s) = %s, not JSON: %s", c.body, got, err)
+		}
+		json.Unmarshal([]byte(c.want), &wantValue)
+		if !reflect.DeepEqual(gotValue, wantValue) {
+			t.Errorf("redactBody(%s) = %s, want %s", c.body, got, c.want)
+		}
+	}
+
+	for body, want := range map[string]string{
+		"service_key=abc123": redactedValue,
+		"Bad Gateway":        "Bad Gateway",
+	} {
+		if got := redactBody([]byte(body)); got != want {
+			t.Errorf("redactBody(%s) = %s, want %s", body, got, want)
+		}
+	}
+}
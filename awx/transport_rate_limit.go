@@ -0,0 +1,100 @@
+package awx
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimitTransport spaces requests to the AWX API evenly to the configured
+// rate and caps the number of requests in flight. The response body is read
+// before the slot is freed, as goawx does not close the body of every
+// response.
+type rateLimitTransport struct {
+	base     http.RoundTripper
+	interval time.Duration
+	slots    chan struct{}
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRateLimitTransport(base http.RoundTripper, requestsPerSecond float64, maxConcurrent int) http.RoundTripper {
+	if requestsPerSecond <= 0 && maxConcurrent <= 0 {
+		return base
+	}
+	t := &rateLimitTransport{base: base}
+	if requestsPerSecond > 0 {
+		t.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := t.wait(ctx); err != nil {
+		t.release()
+		return nil, err
+	}
+
+	defer t.release()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || t.slots == nil {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// wait reserves the next free start time and sleeps until it is reached.
+func (t *rateLimitTransport) wait(ctx context.Context) error {
+	if t.interval <= 0 {
+		return nil
+	}
+	t.mu.Lock()
+	now := time.Now()
+	start := t.next
+	if start.Before(now) {
+		start = now
+	}
+	t.next = start.Add(t.interval)
+	t.mu.Unlock()
+
+	delay := time.Until(start)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (t *rateLimitTransport) release() {
+	if t.slots != nil {
+		<-t.slots
+	}
+}
@@ -0,0 +1,49 @@
+package awx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransportReleasesOnError(t *testing.T) {
+	fake := newFakeAWX(t)
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, 0, 1)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// goawx returns on a 400 without closing the body, which must not hold
+	// the only slot.
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, fake.server.URL+"/api/v2/organizations/", strings.NewReader("{"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("request %d: %s", i, err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("request %d: expected status 400, got %d", i, resp.StatusCode)
+		}
+	}
+}
+
+func TestRateLimitTransportSpacesRequests(t *testing.T) {
+	fake := newFakeAWX(t)
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, 20, 0)}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(fake.server.URL + "/api/v2/ping/")
+		if err != nil {
+			t.Fatalf("request %d: %s", i, err)
+		}
+		resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("3 requests at 20 per second took %s, expected at least 100ms", elapsed)
+	}
+}
@@ -0,0 +1,133 @@
+package awx
+
+import (
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryTransport retries requests which failed because of transient AWX API
+// errors. Idempotent requests are retried on connection errors and on 429,
+// 502, 503 and 504 responses. Other requests are only retried when AWX did
+// not receive them, that is when the connection could not be established or
+// AWX rejected them with 429 before processing.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 {
+			r = req.Clone(req.Context())
+			if req.Body != nil && req.Body != http.NoBody {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+
+		resp, err := t.base.RoundTrip(r)
+		if attempt >= t.maxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if err != nil {
+			log.Printf("[DEBUG] %s %s failed: %s, retrying in %s", req.Method, req.URL.Path, err, wait)
+		} else {
+			log.Printf("[DEBUG] %s %s returned %s, retrying in %s", req.Method, req.URL.Path, resp.Status, wait)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		return isIdempotent(req.Method) || isNotSent(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+	return false
+}
+
+// backoff returns the time to wait before the next attempt. It doubles the
+// minimum wait with every attempt up to the maximum wait, and waits at least
+// as long as a Retry-After header asks for. A minimum wait of 0 retries
+// immediately.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	wait := t.minWait << uint(attempt)
+	if wait>>uint(attempt) != t.minWait || wait > t.maxWait {
+		// overflowed or above the maximum
+		wait = t.maxWait
+	}
+	if wait > 0 {
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+	if resp != nil {
+		if after := retryAfter(resp.Header.Get("Retry-After")); after > wait {
+			wait = after
+		}
+	}
+	return wait
+}
+
+func retryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isNotSent reports whether the request failed before it reached AWX.
+func isNotSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
@@ -0,0 +1,35 @@
+package awx
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryTransportBackoff(t *testing.T) {
+	cases := []struct {
+		minWait, maxWait time.Duration
+		attempt          int
+		low, high        time.Duration
+	}{
+		{0, 30 * time.Second, 0, 0, 0},
+		{0, 30 * time.Second, 5, 0, 0},
+		{time.Second, 30 * time.Second, 0, 500 * time.Millisecond, time.Second},
+		{time.Second, 30 * time.Second, 3, 4 * time.Second, 8 * time.Second},
+		{time.Second, 30 * time.Second, 10, 15 * time.Second, 30 * time.Second},
+		{time.Second, 30 * time.Second, 70, 15 * time.Second, 30 * time.Second},
+		{time.Second, 0, 1, 0, 0},
+	}
+	for _, c := range cases {
+		transport := &retryTransport{minWait: c.minWait, maxWait: c.maxWait}
+		if got := transport.backoff(c.attempt, nil); got < c.low || got > c.high {
+			t.Errorf("backoff(%d) with min %s and max %s = %s, want between %s and %s", c.attempt, c.minWait, c.maxWait, got, c.low, c.high)
+		}
+	}
+
+	transport := &retryTransport{minWait: 0, maxWait: 30 * time.Second}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	if got := transport.backoff(0, resp); got != 2*time.Second {
+		t.Errorf("backoff with Retry-After 2 = %s, want 2s", got)
+	}
+}
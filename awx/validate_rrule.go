@@ -0,0 +1,136 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	rruleDTStartPattern = regexp.MustCompile(`^DTSTART(?:;TZID=([^:]+))?:(\d{8}T\d{6})(Z?)$`)
+	rruleUntilPattern   = regexp.MustCompile(`^\d{8}(T\d{6}Z?)?$`)
+	rruleFrequencies    = map[string]bool{
+		"MINUTELY": true,
+		"HOURLY":   true,
+		"DAILY":    true,
+		"WEEKLY":   true,
+		"MONTHLY":  true,
+		"YEARLY":   true,
+	}
+	rruleParts = map[string]bool{
+		"FREQ":       true,
+		"INTERVAL":   true,
+		"COUNT":      true,
+		"UNTIL":      true,
+		"BYSECOND":   true,
+		"BYMINUTE":   true,
+		"BYHOUR":     true,
+		"BYDAY":      true,
+		"BYMONTHDAY": true,
+		"BYYEARDAY":  true,
+		"BYWEEKNO":   true,
+		"BYMONTH":    true,
+		"BYSETPOS":   true,
+		"WKST":       true,
+	}
+)
+
+// validateRRule checks a schedule rule in the format accepted by AWX, like
+// "DTSTART;TZID=Europe/Berlin:20230101T020000 RRULE:FREQ=DAILY;INTERVAL=1",
+// so that malformed rules are reported at plan time.
+func validateRRule(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	var errs []error
+	dtstart, rules := 0, 0
+	for _, part := range strings.Fields(value) {
+		switch {
+		case strings.HasPrefix(part, "DTSTART"):
+			dtstart++
+			if err := validateRRuleDTStart(part); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s", k, err))
+			}
+		case strings.HasPrefix(part, "RRULE:"), strings.HasPrefix(part, "EXRULE:"):
+			if strings.HasPrefix(part, "RRULE:") {
+				rules++
+			}
+			if err := validateRRuleRule(part); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s", k, err))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("%s: unexpected %q, expected DTSTART, RRULE or EXRULE", k, part))
+		}
+	}
+	if dtstart != 1 {
+		errs = append(errs, fmt.Errorf("%s: exactly one DTSTART is required, got %d", k, dtstart))
+	}
+	if rules == 0 {
+		errs = append(errs, fmt.Errorf("%s: at least one RRULE is required", k))
+	}
+	return nil, errs
+}
+
+func validateRRuleDTStart(part string) error {
+	match := rruleDTStartPattern.FindStringSubmatch(part)
+	if match == nil {
+		return fmt.Errorf("invalid %q, expected DTSTART:YYYYMMDDTHHMMSSZ or DTSTART;TZID=<zone>:YYYYMMDDTHHMMSS", part)
+	}
+	tz, start, utc := match[1], match[2], match[3] == "Z"
+	if _, err := time.Parse("20060102T150405", start); err != nil {
+		return fmt.Errorf("invalid start date %q in %q", start, part)
+	}
+	switch {
+	case tz == "" && !utc:
+		return fmt.Errorf("%q has neither a TZID nor a UTC time ending with Z", part)
+	case tz != "" && utc:
+		return fmt.Errorf("%q has a TZID and a UTC time ending with Z", part)
+	case tz != "":
+		if _, err := time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("unknown time zone %q in %q", tz, part)
+		}
+	}
+	return nil
+}
+
+func validateRRuleRule(part string) error {
+	name, body, _ := strings.Cut(part, ":")
+	values := make(map[string]string)
+	for _, item := range strings.Split(body, ";") {
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return fmt.Errorf("invalid %s part %q, expected KEY=VALUE", name, item)
+		}
+		if !rruleParts[kv[0]] {
+			return fmt.Errorf("unknown %s part %q", name, kv[0])
+		}
+		if _, ok := values[kv[0]]; ok {
+			return fmt.Errorf("%s part %s is set more than once", name, kv[0])
+		}
+		values[kv[0]] = kv[1]
+	}
+
+	if !rruleFrequencies[values["FREQ"]] {
+		return fmt.Errorf("%s requires FREQ to be one of MINUTELY, HOURLY, DAILY, WEEKLY, MONTHLY or YEARLY, got %q", name, values["FREQ"])
+	}
+	for _, key := range []string{"INTERVAL", "COUNT"} {
+		if v, ok := values[key]; ok {
+			if n, err := strconv.Atoi(v); err != nil || n < 1 {
+				return fmt.Errorf("%s requires %s to be a positive number, got %q", name, key, v)
+			}
+		}
+	}
+	if v, ok := values["UNTIL"]; ok {
+		if _, ok := values["COUNT"]; ok {
+			return fmt.Errorf("%s must not set both COUNT and UNTIL", name)
+		}
+		if !rruleUntilPattern.MatchString(v) {
+			return fmt.Errorf("%s requires UNTIL to be a date like 20231231T235959Z, got %q", name, v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package awx
+
+import (
+	"testing"
+)
+
+func TestValidateRRule(t *testing.T) {
+	cases := []struct {
+		rrule string
+		valid bool
+	}{
+		{"DTSTART:20230101T020000Z RRULE:FREQ=DAILY;INTERVAL=1", true},
+		{"DTSTART;TZID=Europe/Berlin:20230101T020000 RRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10", true},
+		{"DTSTART;TZID=America/New_York:20230101T020000 RRULE:FREQ=MONTHLY;UNTIL=20231231T235959Z EXRULE:FREQ=MONTHLY;BYMONTH=12", true},
+		{"DTSTART:20230101T020000 RRULE:FREQ=DAILY", false},
+		{"DTSTART;TZID=Europe/Berlin:20230101T020000Z RRULE:FREQ=DAILY", false},
+		{"DTSTART;TZID=Mars/Olympus:20230101T020000 RRULE:FREQ=DAILY", false},
+		{"DTSTART:20231301T020000Z RRULE:FREQ=DAILY", false},
+		{"DTSTART:2023-01-01T02:00:00Z RRULE:FREQ=DAILY", false},
+		{"RRULE:FREQ=DAILY", false},
+		{"DTSTART:20230101T020000Z", false},
+		{"DTSTART:20230101T020000Z DTSTART:20230102T020000Z RRULE:FREQ=DAILY", false},
+		{"DTSTART:20230101T020000Z RRULE:FREQ=SECONDLY", false},
+		{"DTSTART:20230101T020000Z RRULE:FREQ=DAILY;INTERVAL=0", false},
+		{"DTSTART:20230101T020000Z RRULE:FREQ=DAILY;COUNT=3;UNTIL=20231231T235959Z", false},
+		{"DTSTART:20230101T020000Z RRULE:FREQ=DAILY;FOO=1", false},
+		{"DTSTART:20230101T020000Z RRULE:FREQ=DAILY;FREQ=WEEKLY", false},
+		{"DTSTART:20230101T020000Z RDATE:20230105T020000Z RRULE:FREQ=DAILY", false},
+	}
+	for _, c := range cases {
+		_, errs := validateRRule(c.rrule, "rrule")
+		if c.valid && len(errs) > 0 {
+			t.Errorf("expected %q to be valid, got %v", c.rrule, errs)
+		}
+		if !c.valid && len(errs) == 0 {
+			t.Errorf("expected %q to be invalid", c.rrule)
+		}
+	}
+}